@@ -0,0 +1,286 @@
+package aggregation
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/MunishMummadi/mock-trade-algorithm/config"
+	"github.com/MunishMummadi/mock-trade-algorithm/database"
+	"github.com/MunishMummadi/mock-trade-algorithm/models"
+)
+
+// WeightSource supplies per-strategy weights to the weighted policy
+type WeightSource interface {
+	Weight(strategy string) float64
+}
+
+// AdaptiveConfig controls how strategy weights learn from outcomes
+type AdaptiveConfig struct {
+	Horizon      time.Duration // how long after a signal its outcome is measured
+	LearningRate float64       // multiplicative weights step size
+	Decay        float64       // smoothing factor of the performance averages, between 0 and 1
+	MinWeight    float64       // floor so a strategy can recover after a bad run
+	MaxWeight    float64       // cap so one strategy can't dominate
+}
+
+// AdaptiveConfigFromConfig builds the adaptive weighting settings from
+// application config
+func AdaptiveConfigFromConfig(cfg *config.Config) AdaptiveConfig {
+	return AdaptiveConfig{
+		Horizon:      cfg.AdaptiveHorizon,
+		LearningRate: cfg.AdaptiveLearningRate,
+		Decay:        cfg.AdaptiveDecay,
+		MinWeight:    cfg.AdaptiveMinWeight,
+		MaxWeight:    cfg.AdaptiveMaxWeight,
+	}
+}
+
+// AdaptiveWeights tracks the realized outcome of every strategy signal and
+// turns it into a weight with multiplicative updates: after each outcome a
+// strategy's weight is multiplied by exp(learningRate * reward), where the
+// reward is the signal's return scaled by the strategy's own volatility.
+// Weights are then renormalized to average 1 and clamped.
+//
+// A signal's outcome is the return from its entry price, or the fill price
+// if it contributed to a filled trade, to the price Horizon later, signed by
+// the signal's direction. Signals awaiting their outcome are kept in memory
+// only; weights and their history are persisted.
+type AdaptiveWeights struct {
+	db     *database.Database
+	config AdaptiveConfig
+
+	mu      sync.Mutex
+	stats   map[string]*models.StrategyWeight
+	pending []*pendingSignal
+}
+
+type pendingSignal struct {
+	strategy   string
+	symbol     string
+	direction  float64 // +1 for BUY, -1 for SELL
+	entryPrice decimal.Decimal
+	filled     bool
+	createdAt  time.Time
+}
+
+func NewAdaptiveWeights(db *database.Database, cfg AdaptiveConfig) *AdaptiveWeights {
+	return &AdaptiveWeights{
+		db:     db,
+		config: cfg,
+		stats:  make(map[string]*models.StrategyWeight),
+	}
+}
+
+// Load restores the latest persisted weights
+func (a *AdaptiveWeights) Load() error {
+	weights, err := a.db.GetLatestStrategyWeights()
+	if err != nil {
+		return fmt.Errorf("failed to load strategy weights: %w", err)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for _, weight := range weights {
+		a.stats[weight.Strategy] = weight
+	}
+	return nil
+}
+
+// Weight implements WeightSource. Strategies without a track record weigh 1.
+func (a *AdaptiveWeights) Weight(strategy string) float64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if stats, exists := a.stats[strategy]; exists {
+		return stats.Weight
+	}
+	return 1.0
+}
+
+// Weights returns the current snapshot of every tracked strategy
+func (a *AdaptiveWeights) Weights() []models.StrategyWeight {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	weights := make([]models.StrategyWeight, 0, len(a.stats))
+	for _, stats := range a.stats {
+		weights = append(weights, *stats)
+	}
+	sort.Slice(weights, func(i, j int) bool {
+		return weights[i].Strategy < weights[j].Strategy
+	})
+	return weights
+}
+
+// Observe records signals so their outcome can be scored once the horizon
+// has passed
+func (a *AdaptiveWeights) Observe(signals []*models.TradingSignal) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for _, signal := range signals {
+		direction := 0.0
+		switch signal.Signal {
+		case "BUY":
+			direction = 1
+		case "SELL":
+			direction = -1
+		}
+		if direction == 0 || !signal.Price.IsPositive() {
+			continue
+		}
+
+		a.pending = append(a.pending, &pendingSignal{
+			strategy:   signal.Strategy,
+			symbol:     signal.Symbol,
+			direction:  direction,
+			entryPrice: signal.Price,
+			createdAt:  signal.CreatedAt,
+		})
+	}
+}
+
+// ObserveFill uses a filled trade's price as the entry price of the pending
+// signals that agreed with it, so slippage counts against the strategies
+func (a *AdaptiveWeights) ObserveFill(trade *models.Trade) {
+	if trade.Status != models.TradeStatusFilled {
+		return
+	}
+
+	direction := 1.0
+	if trade.Side == models.OrderSideSell {
+		direction = -1
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for _, p := range a.pending {
+		if p.symbol == trade.Symbol && p.direction == direction && !p.filled && !p.createdAt.After(trade.CreatedAt) {
+			p.entryPrice = trade.FillPrice
+			p.filled = true
+		}
+	}
+}
+
+// Resolve scores every pending signal older than the horizon against the
+// current prices, updates the weights and persists a snapshot of each
+// strategy that changed, including those whose weight only moved through
+// renormalization. The weights live in memory, so a snapshot that
+// fails to save is logged and the rest are still saved; the error reports
+// how many failed.
+func (a *AdaptiveWeights) Resolve(prices map[string]decimal.Decimal, now time.Time) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	before := make(map[string]float64, len(a.stats))
+	for strategy, stats := range a.stats {
+		before[strategy] = stats.Weight
+	}
+
+	changed := make(map[string]bool)
+	remaining := a.pending[:0]
+	for _, p := range a.pending {
+		price, priced := prices[p.symbol]
+		if now.Sub(p.createdAt) < a.config.Horizon || !priced {
+			remaining = append(remaining, p)
+			continue
+		}
+
+		ret := p.direction * (price.Div(p.entryPrice).InexactFloat64() - 1)
+		a.update(p.strategy, ret)
+		changed[p.strategy] = true
+	}
+	a.pending = remaining
+
+	if len(changed) == 0 {
+		return nil
+	}
+	a.normalize()
+	for strategy, weight := range before {
+		if a.stats[strategy].Weight != weight {
+			changed[strategy] = true
+		}
+	}
+
+	failed := 0
+	for _, strategy := range sortedKeys(changed) {
+		snapshot := *a.stats[strategy]
+		snapshot.ID = 0
+		snapshot.CreatedAt = now
+		if err := a.db.CreateStrategyWeight(&snapshot); err != nil {
+			log.Printf("Warning: %v", err)
+			failed++
+			continue
+		}
+		log.Printf("Strategy weight %s: %.3f (hit rate %.0f%%, return/vol %.2f, %d samples)",
+			strategy, snapshot.Weight, snapshot.HitRate*100, snapshot.RiskAdjustedReturn(), snapshot.Samples)
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to save %d of %d strategy weight snapshots", failed, len(changed))
+	}
+	return nil
+}
+
+// update folds one signal return into a strategy's averages and weight
+func (a *AdaptiveWeights) update(strategy string, ret float64) {
+	stats, exists := a.stats[strategy]
+	if !exists {
+		stats = &models.StrategyWeight{Strategy: strategy, Weight: 1.0, HitRate: 0.5}
+		a.stats[strategy] = stats
+	}
+
+	hit := 0.0
+	if ret > 0 {
+		hit = 1
+	}
+
+	decay := a.config.Decay
+	deviation := ret - stats.MeanReturn
+	stats.HitRate += decay * (hit - stats.HitRate)
+	stats.MeanReturn += decay * deviation
+	stats.Volatility = math.Sqrt((1 - decay) * (stats.Volatility*stats.Volatility + decay*deviation*deviation))
+	stats.Samples++
+
+	// Scale by the strategy's own volatility so noisy strategies don't swing
+	// their weight more than steady ones
+	reward := math.Copysign(1, ret)
+	if stats.Volatility > 0 {
+		reward = math.Max(-2, math.Min(2, ret/stats.Volatility))
+	}
+	stats.Weight *= math.Exp(a.config.LearningRate * reward)
+}
+
+// normalize rescales weights to average 1 and clamps them to the bounds
+func (a *AdaptiveWeights) normalize() {
+	if len(a.stats) == 0 {
+		return
+	}
+
+	total := 0.0
+	for _, stats := range a.stats {
+		total += stats.Weight
+	}
+	mean := total / float64(len(a.stats))
+	if mean <= 0 {
+		return
+	}
+
+	for _, stats := range a.stats {
+		stats.Weight = math.Max(a.config.MinWeight, math.Min(a.config.MaxWeight, stats.Weight/mean))
+	}
+}
+
+func sortedKeys[V any](set map[string]V) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,258 @@
+// Package aggregation combines the signals of several strategies for one
+// symbol into a single trade decision.
+package aggregation
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/MunishMummadi/mock-trade-algorithm/models"
+)
+
+// Policy names accepted by New
+const (
+	PolicyMajority  = "majority"
+	PolicyWeighted  = "weighted"
+	PolicyUnanimous = "unanimous"
+	PolicyAnyStrong = "any_strong"
+	PolicyAdaptive  = "adaptive"
+)
+
+// Decision is the combined view of a symbol's signals
+type Decision struct {
+	Signal string // BUY, SELL, or empty for no trade
+
+	// Multiplier scales the per-trade risk amount when sizing a position
+	Multiplier float64
+
+	// TargetWeight is the largest target weight among agreeing signals
+	TargetWeight float64
+
+	// Notes explains the decision and is stored on the trade
+	Notes string
+}
+
+// Aggregator combines signals into a decision
+type Aggregator interface {
+	Name() string
+	Aggregate(signals []*models.TradingSignal) Decision
+}
+
+// Options holds the parameters of the built-in policies
+type Options struct {
+	MinVotes          int                // majority and unanimous: agreeing signals required
+	Weights           map[string]float64 // weighted: lower-cased strategy name -> weight, missing names weigh 1
+	WeightedThreshold float64            // weighted: net score needed to trade, between 0 and 1
+	StrongThreshold   float64            // any_strong: strength a single signal needs
+	Adaptive          WeightSource       // adaptive: learned weights, applied on top of Weights
+}
+
+// New creates a built-in aggregator by policy name
+func New(policy string, opts Options) (Aggregator, error) {
+	switch policy {
+	case PolicyMajority:
+		return &Majority{MinVotes: opts.MinVotes}, nil
+	case PolicyWeighted:
+		return &Weighted{Weights: opts.Weights, Threshold: opts.WeightedThreshold}, nil
+	case PolicyUnanimous:
+		return &Unanimous{MinVotes: opts.MinVotes}, nil
+	case PolicyAnyStrong:
+		return &AnyStrong{Threshold: opts.StrongThreshold}, nil
+	case PolicyAdaptive:
+		if opts.Adaptive == nil {
+			return nil, fmt.Errorf("aggregation policy %q needs a weight source", policy)
+		}
+		return &Weighted{Weights: opts.Weights, Threshold: opts.WeightedThreshold, Source: opts.Adaptive}, nil
+	default:
+		return nil, fmt.Errorf("unknown aggregation policy %q", policy)
+	}
+}
+
+// Majority trades when more signals agree on one side than the other and at
+// least MinVotes agree. The multiplier is the sum of all signal strengths.
+type Majority struct {
+	MinVotes int
+}
+
+func (m *Majority) Name() string {
+	return PolicyMajority
+}
+
+func (m *Majority) Aggregate(signals []*models.TradingSignal) Decision {
+	t := tally(signals)
+
+	side := ""
+	if t.buys > t.sells && t.buys >= m.MinVotes {
+		side = "BUY"
+	} else if t.sells > t.buys && t.sells >= m.MinVotes {
+		side = "SELL"
+	}
+
+	return t.decision(m.Name(), side, t.totalStrength)
+}
+
+// Weighted trades on the weighted net score of the signals:
+// sum(weight * strength * direction) / sum(weight), where direction is +1 for
+// BUY and -1 for SELL. It trades when the score's magnitude reaches
+// Threshold; the multiplier is the weighted strength of the agreeing side.
+// With a Source the static weights are multiplied by its learned weights,
+// which makes this the adaptive policy.
+type Weighted struct {
+	Weights   map[string]float64
+	Threshold float64
+	Source    WeightSource
+}
+
+func (w *Weighted) Name() string {
+	if w.Source != nil {
+		return PolicyAdaptive
+	}
+	return PolicyWeighted
+}
+
+func (w *Weighted) weight(strategy string) float64 {
+	weight := 1.0
+	if static, exists := w.Weights[strings.ToLower(strategy)]; exists {
+		weight = static
+	}
+	if w.Source != nil {
+		weight *= w.Source.Weight(strategy)
+	}
+	return weight
+}
+
+func (w *Weighted) Aggregate(signals []*models.TradingSignal) Decision {
+	t := tally(signals)
+
+	var score, totalWeight, buyStrength, sellStrength float64
+	for _, signal := range signals {
+		weight := w.weight(signal.Strategy)
+		totalWeight += weight
+		switch signal.Signal {
+		case "BUY":
+			score += weight * signal.Strength
+			buyStrength += weight * signal.Strength
+		case "SELL":
+			score -= weight * signal.Strength
+			sellStrength += weight * signal.Strength
+		}
+	}
+	if totalWeight > 0 {
+		score /= totalWeight
+	}
+
+	decision := t.decision(w.Name(), "", 0)
+	if score >= w.Threshold && score > 0 {
+		decision = t.decision(w.Name(), "BUY", buyStrength)
+	} else if -score >= w.Threshold && score < 0 {
+		decision = t.decision(w.Name(), "SELL", sellStrength)
+	}
+	decision.Notes += fmt.Sprintf(" score=%.2f", score)
+	return decision
+}
+
+// Unanimous trades only when every signal agrees and at least MinVotes were
+// produced. The multiplier is the sum of their strengths.
+type Unanimous struct {
+	MinVotes int
+}
+
+func (u *Unanimous) Name() string {
+	return PolicyUnanimous
+}
+
+func (u *Unanimous) Aggregate(signals []*models.TradingSignal) Decision {
+	t := tally(signals)
+
+	side := ""
+	if t.sells == 0 && t.buys >= u.MinVotes {
+		side = "BUY"
+	} else if t.buys == 0 && t.sells >= u.MinVotes {
+		side = "SELL"
+	}
+
+	return t.decision(u.Name(), side, t.totalStrength)
+}
+
+// AnyStrong trades on a single signal whose strength reaches Threshold, as
+// long as no opposing signal is equally strong. The multiplier is the
+// strongest signal's strength.
+type AnyStrong struct {
+	Threshold float64
+}
+
+func (a *AnyStrong) Name() string {
+	return PolicyAnyStrong
+}
+
+func (a *AnyStrong) Aggregate(signals []*models.TradingSignal) Decision {
+	t := tally(signals)
+
+	var strongestBuy, strongestSell float64
+	for _, signal := range signals {
+		switch signal.Signal {
+		case "BUY":
+			strongestBuy = math.Max(strongestBuy, signal.Strength)
+		case "SELL":
+			strongestSell = math.Max(strongestSell, signal.Strength)
+		}
+	}
+
+	if strongestBuy >= a.Threshold && strongestBuy > strongestSell {
+		return t.decision(a.Name(), "BUY", strongestBuy)
+	}
+	if strongestSell >= a.Threshold && strongestSell > strongestBuy {
+		return t.decision(a.Name(), "SELL", strongestSell)
+	}
+	return t.decision(a.Name(), "", 0)
+}
+
+// votes summarizes a set of signals
+type votes struct {
+	buys, sells   int
+	totalStrength float64
+	buyWeight     float64 // largest target weight among BUY signals
+	buyers        []string
+	sellers       []string
+}
+
+func tally(signals []*models.TradingSignal) votes {
+	var t votes
+	for _, signal := range signals {
+		switch signal.Signal {
+		case "BUY":
+			t.buys++
+			t.buyWeight = math.Max(t.buyWeight, signal.TargetWeight)
+			t.buyers = append(t.buyers, signal.Strategy)
+		case "SELL":
+			t.sells++
+			t.sellers = append(t.sellers, signal.Strategy)
+		}
+		t.totalStrength += signal.Strength
+	}
+	sort.Strings(t.buyers)
+	sort.Strings(t.sellers)
+	return t
+}
+
+// decision builds a Decision with notes like
+// "majority: BUY 3 buy [A, B, C] / 1 sell [D]"
+func (t votes) decision(policy, side string, multiplier float64) Decision {
+	outcome := side
+	if outcome == "" {
+		outcome = "HOLD"
+	}
+
+	decision := Decision{
+		Signal:     side,
+		Multiplier: multiplier,
+		Notes: fmt.Sprintf("%s: %s %d buy [%s] / %d sell [%s]", policy, outcome,
+			t.buys, strings.Join(t.buyers, ", "), t.sells, strings.Join(t.sellers, ", ")),
+	}
+	if side == "BUY" {
+		decision.TargetWeight = t.buyWeight
+	}
+	return decision
+}
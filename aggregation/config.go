@@ -0,0 +1,152 @@
+package aggregation
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/MunishMummadi/mock-trade-algorithm/config"
+)
+
+// Router picks the aggregator used for each symbol
+type Router struct {
+	fallback Aggregator
+	bySymbol map[string]Aggregator
+}
+
+// NewRouter creates a router that uses fallback for every symbol without an
+// override. Override symbols are matched ignoring case.
+func NewRouter(fallback Aggregator, overrides map[string]Aggregator) *Router {
+	bySymbol := make(map[string]Aggregator, len(overrides))
+	for symbol, aggregator := range overrides {
+		bySymbol[strings.ToLower(symbol)] = aggregator
+	}
+	return &Router{fallback: fallback, bySymbol: bySymbol}
+}
+
+// For returns the aggregator for a symbol
+func (r *Router) For(symbol string) Aggregator {
+	if aggregator, exists := r.bySymbol[strings.ToLower(symbol)]; exists {
+		return aggregator
+	}
+	return r.fallback
+}
+
+// FromConfig builds the router from application config; adaptive supplies
+// the learned weights for the adaptive policy
+func FromConfig(cfg *config.Config, adaptive WeightSource) (*Router, error) {
+	weights, err := ParseWeights(cfg.StrategyWeights)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := Options{
+		MinVotes:          int(cfg.AggregationMinVotes),
+		Weights:           weights,
+		WeightedThreshold: cfg.AggregationWeightedThreshold,
+		StrongThreshold:   cfg.AggregationStrongThreshold,
+		Adaptive:          adaptive,
+	}
+
+	fallback, err := New(cfg.AggregationPolicy, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	overrides := make(map[string]Aggregator)
+	policies, err := parseAssignments(cfg.AggregationOverrides)
+	if err != nil {
+		return nil, fmt.Errorf("invalid AGGREGATION_OVERRIDES: %w", err)
+	}
+	for symbol, policy := range policies {
+		if _, exists := overrides[strings.ToLower(symbol)]; exists {
+			return nil, fmt.Errorf("symbol %s is in AGGREGATION_OVERRIDES twice", symbol)
+		}
+		aggregator, err := New(policy, opts)
+		if err != nil {
+			return nil, fmt.Errorf("invalid aggregation policy for %s: %w", symbol, err)
+		}
+		overrides[strings.ToLower(symbol)] = aggregator
+	}
+
+	return NewRouter(fallback, overrides), nil
+}
+
+// CheckKeys fails when STRATEGY_WEIGHTS names a strategy that doesn't exist
+// or AGGREGATION_OVERRIDES a symbol outside the watchlist, which would
+// otherwise be ignored without notice. Names are compared ignoring case.
+func CheckKeys(cfg *config.Config, strategyNames, watchlist []string) error {
+	weights, err := ParseWeights(cfg.StrategyWeights)
+	if err != nil {
+		return err
+	}
+	known := make(map[string]bool, len(strategyNames))
+	for _, name := range strategyNames {
+		known[strings.ToLower(name)] = true
+	}
+	for _, name := range sortedKeys(weights) {
+		if !known[name] {
+			return fmt.Errorf("unknown strategy %q in STRATEGY_WEIGHTS", name)
+		}
+	}
+
+	policies, err := parseAssignments(cfg.AggregationOverrides)
+	if err != nil {
+		return fmt.Errorf("invalid AGGREGATION_OVERRIDES: %w", err)
+	}
+	watched := make(map[string]bool, len(watchlist))
+	for _, symbol := range watchlist {
+		watched[strings.ToLower(symbol)] = true
+	}
+	for _, symbol := range sortedKeys(policies) {
+		if !watched[strings.ToLower(symbol)] {
+			return fmt.Errorf("symbol %q in AGGREGATION_OVERRIDES is not in the watchlist", symbol)
+		}
+	}
+	return nil
+}
+
+// ParseWeights parses a comma separated list of "strategy=weight" pairs such
+// as "SMA Crossover=1.5,RSI Strategy=0.5". Strategy names are lower-cased.
+func ParseWeights(value string) (map[string]float64, error) {
+	assignments, err := parseAssignments(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid STRATEGY_WEIGHTS: %w", err)
+	}
+
+	weights := make(map[string]float64, len(assignments))
+	for name, raw := range assignments {
+		weight, err := strconv.ParseFloat(raw, 64)
+		if err != nil || weight < 0 {
+			return nil, fmt.Errorf("invalid weight %q for strategy %s", raw, name)
+		}
+		key := strings.ToLower(name)
+		if _, exists := weights[key]; exists {
+			return nil, fmt.Errorf("strategy %s is in STRATEGY_WEIGHTS twice", name)
+		}
+		weights[key] = weight
+	}
+	return weights, nil
+}
+
+// parseAssignments parses "key=value" pairs separated by commas. A key may
+// only be assigned once.
+func parseAssignments(value string) (map[string]string, error) {
+	assignments := make(map[string]string)
+	if strings.TrimSpace(value) == "" {
+		return assignments, nil
+	}
+
+	for _, part := range strings.Split(value, ",") {
+		key, val, found := strings.Cut(part, "=")
+		key, val = strings.TrimSpace(key), strings.TrimSpace(val)
+		if !found || key == "" || val == "" {
+			return nil, fmt.Errorf("expected key=value, got %q", strings.TrimSpace(part))
+		}
+		if _, exists := assignments[key]; exists {
+			return nil, fmt.Errorf("%s is assigned twice", key)
+		}
+		assignments[key] = val
+	}
+	return assignments, nil
+}
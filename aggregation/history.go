@@ -0,0 +1,32 @@
+package aggregation
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/MunishMummadi/mock-trade-algorithm/models"
+)
+
+// PrintWeightHistory writes strategy weight snapshots as a plain text
+// table, in the order given
+func PrintWeightHistory(w io.Writer, history []*models.StrategyWeight) error {
+	if len(history) == 0 {
+		fmt.Fprintln(w, "No strategy weight snapshots recorded")
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "time\tstrategy\tweight\thit rate\tmean return\tvol\treturn/vol\tsamples")
+	for _, s := range history {
+		fmt.Fprintf(tw, "%s\t%s\t%.3f\t%.1f%%\t%.3f%%\t%.3f%%\t%.2f\t%d\n",
+			s.CreatedAt.Format(time.RFC3339), s.Strategy, s.Weight, s.HitRate*100,
+			s.MeanReturn*100, s.Volatility*100, s.RiskAdjustedReturn(), s.Samples)
+	}
+
+	if err := tw.Flush(); err != nil {
+		return fmt.Errorf("failed to write weight history: %w", err)
+	}
+	return nil
+}
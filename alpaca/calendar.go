@@ -0,0 +1,50 @@
+package alpaca
+
+import (
+	"time"
+
+	"github.com/MunishMummadi/mock-trade-algorithm/models"
+)
+
+// MarketCalendar decides whether an asset class is tradable at a given time
+type MarketCalendar interface {
+	IsOpen(t time.Time) bool
+	Name() string
+}
+
+// EquityCalendar models regular US equity hours: Monday-Friday 9:30 AM - 4:00 PM
+type EquityCalendar struct{}
+
+func (EquityCalendar) IsOpen(t time.Time) bool {
+	if t.Weekday() == time.Saturday || t.Weekday() == time.Sunday {
+		return false
+	}
+
+	hour := t.Hour()
+	return hour >= 9 && hour < 16
+}
+
+func (EquityCalendar) Name() string {
+	return "equity regular session"
+}
+
+// CryptoCalendar models crypto venues, which trade around the clock every day
+type CryptoCalendar struct{}
+
+func (CryptoCalendar) IsOpen(t time.Time) bool {
+	return true
+}
+
+func (CryptoCalendar) Name() string {
+	return "crypto 24/7"
+}
+
+// CalendarFor returns the session rules for an asset class
+func CalendarFor(class models.AssetClass) MarketCalendar {
+	switch class {
+	case models.AssetClassCrypto:
+		return CryptoCalendar{}
+	default:
+		return EquityCalendar{}
+	}
+}
@@ -17,6 +17,8 @@ type Client struct {
 	config       *config.Config
 	mockPrices   map[string]decimal.Decimal
 	mockAccounts map[string]decimal.Decimal
+	orders       map[string]*MockOrder // every order placed, by ID
+	orderSeq     int64
 }
 
 type MockAccount struct {
@@ -43,6 +45,7 @@ type MockOrder struct {
 	Status    string          `json:"status"`
 	Price     decimal.Decimal `json:"price"`
 	CreatedAt time.Time       `json:"created_at"`
+	FilledAt  *time.Time      `json:"filled_at"`
 }
 
 type MockBar struct {
@@ -59,30 +62,26 @@ func NewClient(cfg *config.Config) (*Client, error) {
 		config:       cfg,
 		mockPrices:   make(map[string]decimal.Decimal),
 		mockAccounts: make(map[string]decimal.Decimal),
+		orders:       make(map[string]*MockOrder),
 	}
 
-	// Initialize mock prices for common stocks
-	client.initializeMockPrices()
-
 	log.Println("Successfully initialized Mock Alpaca API client")
 	return client, nil
 }
 
-func (c *Client) initializeMockPrices() {
-	// Initialize with realistic stock prices
-	stockPrices := map[string]float64{
-		"AAPL":  175.50,
-		"GOOGL": 135.25,
-		"MSFT":  378.85,
-		"TSLA":  238.45,
-		"AMZN":  145.30,
-		"NVDA":  875.25,
-		"META":  485.60,
-		"NFLX":  425.75,
+// SeedMarket initializes the mock market with each asset's reference price
+func (c *Client) SeedMarket(assets []*models.Asset) {
+	for _, asset := range assets {
+		if _, seeded := c.mockPrices[asset.Symbol]; !seeded {
+			c.mockPrices[asset.Symbol] = asset.ReferencePrice
+		}
 	}
+}
 
-	for symbol, price := range stockPrices {
-		c.mockPrices[symbol] = decimal.NewFromFloat(price)
+// ApplySplit rescales the mock market price of a symbol on a split's ex-date
+func (c *Client) ApplySplit(symbol string, ratio decimal.Decimal) {
+	if price, exists := c.mockPrices[symbol]; exists && ratio.IsPositive() {
+		c.mockPrices[symbol] = price.Div(ratio)
 	}
 }
 
@@ -96,15 +95,14 @@ func (c *Client) GetAccount(ctx context.Context) (*MockAccount, error) {
 	}, nil
 }
 
+// IsMarketOpen reports whether the equity market is open
 func (c *Client) IsMarketOpen(ctx context.Context) (bool, error) {
-	now := time.Now()
-	// Simple mock: market is open Monday-Friday 9:30 AM - 4:00 PM ET
-	if now.Weekday() == time.Saturday || now.Weekday() == time.Sunday {
-		return false, nil
-	}
+	return c.IsMarketOpenFor(ctx, models.AssetClassEquity)
+}
 
-	hour := now.Hour()
-	return hour >= 9 && hour < 16, nil
+// IsMarketOpenFor reports whether the given asset class is currently tradable
+func (c *Client) IsMarketOpenFor(ctx context.Context, class models.AssetClass) (bool, error) {
+	return CalendarFor(class).IsOpen(time.Now()), nil
 }
 
 func (c *Client) GetCurrentPrice(ctx context.Context, symbol string) (decimal.Decimal, error) {
@@ -188,14 +186,11 @@ func (c *Client) GetBars(ctx context.Context, symbol string, timeframe interface
 	return bars, nil
 }
 
-func (c *Client) MockPlaceOrder(trade *models.Trade) error {
-	// Simulate order processing delay
-	time.Sleep(time.Duration(rand.Intn(200)+50) * time.Millisecond)
-
+// MockPlaceOrder fills an order against the mock market and records it so
+// GetOrder can report it later. Latency and rejections are injected by
+// wrapping the client in a FaultInjector.
+func (c *Client) MockPlaceOrder(ctx context.Context, trade *models.Trade) error {
 	// Get current price for the symbol
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-
 	currentPrice, err := c.GetCurrentPrice(ctx, trade.Symbol)
 	if err != nil {
 		return fmt.Errorf("failed to get current price for mock order: %w", err)
@@ -220,7 +215,8 @@ func (c *Client) MockPlaceOrder(trade *models.Trade) error {
 		} else {
 			// Order doesn't fill immediately
 			trade.Status = models.TradeStatusPending
-			trade.AlpacaOrderID = fmt.Sprintf("mock_pending_%d_%s", time.Now().Unix(), trade.Symbol)
+			trade.AlpacaOrderID = c.newOrderID("mock_pending", trade.Symbol)
+			c.recordOrder(trade)
 			return nil
 		}
 	}
@@ -228,15 +224,9 @@ func (c *Client) MockPlaceOrder(trade *models.Trade) error {
 	// Mock commission (Alpaca is commission-free, but we can simulate other costs)
 	commission := decimal.Zero
 
-	// Random chance of rejection (1% for realism)
-	if rand.Float64() < 0.01 {
-		trade.Status = models.TradeStatusRejected
-		trade.AlpacaOrderID = fmt.Sprintf("mock_rejected_%d_%s", time.Now().Unix(), trade.Symbol)
-		return fmt.Errorf("mock order rejected due to insufficient funds or market conditions")
-	}
-
 	trade.MarkFilled(fillPrice, commission)
-	trade.AlpacaOrderID = fmt.Sprintf("mock_%d_%s", time.Now().Unix(), trade.Symbol)
+	trade.AlpacaOrderID = c.newOrderID("mock", trade.Symbol)
+	c.recordOrder(trade)
 
 	log.Printf("Mock order filled: %s %s %s @ $%.2f",
 		trade.Side, trade.Quantity.String(), trade.Symbol, fillPrice.InexactFloat64())
@@ -263,21 +253,39 @@ func (c *Client) calculateSlippage(trade *models.Trade) decimal.Decimal {
 	return currentPrice.Mul(slippage)
 }
 
-// Simplified methods that don't rely on complex external APIs
+// newOrderID returns a unique broker order ID
+func (c *Client) newOrderID(prefix, symbol string) string {
+	c.orderSeq++
+	return fmt.Sprintf("%s_%d_%d_%s", prefix, time.Now().Unix(), c.orderSeq, symbol)
+}
+
+// recordOrder remembers the broker's view of an order
+func (c *Client) recordOrder(trade *models.Trade) {
+	c.orders[trade.AlpacaOrderID] = &MockOrder{
+		ID:        trade.AlpacaOrderID,
+		Symbol:    trade.Symbol,
+		Qty:       trade.Quantity,
+		Side:      string(trade.Side),
+		OrderType: string(trade.Type),
+		Status:    string(trade.Status),
+		Price:     trade.FillPrice,
+		CreatedAt: trade.CreatedAt,
+		FilledAt:  trade.FilledAt,
+	}
+}
+
+// GetOrder returns an order placed with the mock broker, with its fill
+// price as Price once it has filled
 func (c *Client) GetOrder(ctx context.Context, orderID string) (*MockOrder, error) {
-	// Mock implementation
-	return &MockOrder{
-		ID:        orderID,
-		Symbol:    "AAPL",
-		Qty:       decimal.NewFromInt(10),
-		Side:      "buy",
-		OrderType: "market",
-		Status:    "filled",
-		Price:     decimal.NewFromFloat(175.50),
-		CreatedAt: time.Now(),
-	}, nil
+	order, exists := c.orders[orderID]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrOrderNotFound, orderID)
+	}
+	copied := *order
+	return &copied, nil
 }
 
+// Simplified methods that don't rely on complex external APIs
 func (c *Client) CancelOrder(ctx context.Context, orderID string) error {
 	// Mock implementation
 	log.Printf("Mock: Cancelled order %s", orderID)
@@ -0,0 +1,224 @@
+package alpaca
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/MunishMummadi/mock-trade-algorithm/config"
+	"github.com/MunishMummadi/mock-trade-algorithm/models"
+)
+
+var (
+	ErrOrderRejected     = errors.New("mock order rejected due to insufficient funds or market conditions")
+	ErrBrokerTimeout     = errors.New("broker did not acknowledge the order in time")
+	ErrBrokerUnavailable = errors.New("broker is unavailable")
+	ErrDuplicateFill     = errors.New("broker reported the same fill twice")
+	ErrOrderNotFound     = errors.New("broker has no such order")
+)
+
+// OrderPlacer is the part of the broker the trading engine sends orders to
+// and asks about orders whose acknowledgement never arrived
+type OrderPlacer interface {
+	MockPlaceOrder(ctx context.Context, trade *models.Trade) error
+	GetOrder(ctx context.Context, orderID string) (*MockOrder, error)
+}
+
+// OutageWindow is a period, relative to when the injector was created,
+// during which the broker refuses every order
+type OutageWindow struct {
+	Start time.Duration
+	End   time.Duration
+}
+
+// FaultConfig sets how often each broker fault is injected. Rates are
+// probabilities between 0 and 1 evaluated per order.
+type FaultConfig struct {
+	Seed              int64 // 0 seeds from the clock
+	MinLatency        time.Duration
+	MaxLatency        time.Duration
+	RejectRate        float64
+	TimeoutRate       float64
+	Timeout           time.Duration // how long a lost acknowledgement is waited for when the caller sets no deadline
+	AckDelayRate      float64
+	AckDelay          time.Duration
+	DuplicateFillRate float64
+	Outages           []OutageWindow
+}
+
+// FaultConfigFromConfig builds the fault settings from application config
+func FaultConfigFromConfig(cfg *config.Config) (FaultConfig, error) {
+	outages, err := ParseOutageWindows(cfg.BrokerOutages)
+	if err != nil {
+		return FaultConfig{}, err
+	}
+
+	return FaultConfig{
+		Seed:              cfg.BrokerFaultSeed,
+		MinLatency:        cfg.BrokerMinLatency,
+		MaxLatency:        cfg.BrokerMaxLatency,
+		RejectRate:        cfg.BrokerRejectRate,
+		TimeoutRate:       cfg.BrokerTimeoutRate,
+		Timeout:           cfg.BrokerTimeout,
+		AckDelayRate:      cfg.BrokerAckDelayRate,
+		AckDelay:          cfg.BrokerAckDelay,
+		DuplicateFillRate: cfg.BrokerDuplicateFillRate,
+		Outages:           outages,
+	}, nil
+}
+
+// ParseOutageWindows parses a comma separated list of "start-end" offsets
+// such as "10m-15m,1h-1h5m"
+func ParseOutageWindows(value string) ([]OutageWindow, error) {
+	var windows []OutageWindow
+	if strings.TrimSpace(value) == "" {
+		return windows, nil
+	}
+
+	for _, part := range strings.Split(value, ",") {
+		bounds := strings.SplitN(strings.TrimSpace(part), "-", 2)
+		if len(bounds) != 2 {
+			return nil, fmt.Errorf("invalid outage window %q", part)
+		}
+
+		start, err := time.ParseDuration(bounds[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid outage start %q: %w", bounds[0], err)
+		}
+		end, err := time.ParseDuration(bounds[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid outage end %q: %w", bounds[1], err)
+		}
+		if end <= start {
+			return nil, fmt.Errorf("outage window %q ends before it starts", part)
+		}
+
+		windows = append(windows, OutageWindow{Start: start, End: end})
+	}
+
+	return windows, nil
+}
+
+// FaultInjector wraps a broker and injects latency, rejections, timeouts,
+// delayed acknowledgements, duplicate fills and outages. With a fixed seed
+// the sequence of faults is reproducible.
+type FaultInjector struct {
+	inner   OrderPlacer
+	config  FaultConfig
+	rng     *rand.Rand
+	started time.Time
+}
+
+func NewFaultInjector(inner OrderPlacer, cfg FaultConfig) *FaultInjector {
+	seed := cfg.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	return &FaultInjector{
+		inner:   inner,
+		config:  cfg,
+		rng:     rand.New(rand.NewSource(seed)),
+		started: time.Now(),
+	}
+}
+
+// MockPlaceOrder implements OrderPlacer. Injected delays end early when ctx
+// is done. An order whose acknowledgement is lost or arrives after ctx's
+// deadline still reaches the broker: the trade is left pending with the
+// broker's order ID, so its outcome can be reconciled with GetOrder.
+func (f *FaultInjector) MockPlaceOrder(ctx context.Context, trade *models.Trade) error {
+	if f.inOutage(time.Now()) {
+		trade.AlpacaOrderID = fmt.Sprintf("mock_unavailable_%d_%s", time.Now().Unix(), trade.Symbol)
+		return ErrBrokerUnavailable
+	}
+
+	// Simulate order processing delay; the order has not been sent yet
+	if err := wait(ctx, f.latency()); err != nil {
+		return err
+	}
+
+	if f.roll(f.config.RejectRate) {
+		trade.Status = models.TradeStatusRejected
+		trade.AlpacaOrderID = fmt.Sprintf("mock_rejected_%d_%s", time.Now().Unix(), trade.Symbol)
+		return ErrOrderRejected
+	}
+
+	if f.roll(f.config.TimeoutRate) {
+		f.placeUnacknowledged(ctx, trade)
+		wait(ctx, f.config.Timeout)
+		return ErrBrokerTimeout
+	}
+
+	if f.roll(f.config.AckDelayRate) {
+		log.Printf("Fault injection: delaying acknowledgement for %s by %v", trade.Symbol, f.config.AckDelay)
+		if err := wait(ctx, f.config.AckDelay); err != nil {
+			f.placeUnacknowledged(ctx, trade)
+			return ErrBrokerTimeout
+		}
+	}
+
+	if err := f.inner.MockPlaceOrder(ctx, trade); err != nil {
+		return err
+	}
+
+	if trade.Status == models.TradeStatusFilled && f.roll(f.config.DuplicateFillRate) {
+		return ErrDuplicateFill
+	}
+
+	return nil
+}
+
+// GetOrder implements OrderPlacer
+func (f *FaultInjector) GetOrder(ctx context.Context, orderID string) (*MockOrder, error) {
+	return f.inner.GetOrder(ctx, orderID)
+}
+
+// placeUnacknowledged sends the order to the broker without telling the
+// caller how it ended: the trade only learns the broker's order ID
+func (f *FaultInjector) placeUnacknowledged(ctx context.Context, trade *models.Trade) {
+	placed := *trade
+	if err := f.inner.MockPlaceOrder(ctx, &placed); err != nil {
+		log.Printf("Fault injection: unacknowledged order for %s failed: %v", trade.Symbol, err)
+	}
+	trade.AlpacaOrderID = placed.AlpacaOrderID
+}
+
+// wait pauses for d, returning early with ctx's error if ctx is done first
+func wait(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (f *FaultInjector) inOutage(now time.Time) bool {
+	elapsed := now.Sub(f.started)
+	for _, window := range f.config.Outages {
+		if elapsed >= window.Start && elapsed < window.End {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *FaultInjector) latency() time.Duration {
+	spread := f.config.MaxLatency - f.config.MinLatency
+	if spread <= 0 {
+		return f.config.MinLatency
+	}
+	return f.config.MinLatency + time.Duration(f.rng.Int63n(int64(spread)))
+}
+
+func (f *FaultInjector) roll(rate float64) bool {
+	return rate > 0 && f.rng.Float64() < rate
+}
@@ -0,0 +1,195 @@
+package alpaca
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/MunishMummadi/mock-trade-algorithm/config"
+	"github.com/MunishMummadi/mock-trade-algorithm/models"
+)
+
+func newTestInjector(t *testing.T, cfg FaultConfig) (*FaultInjector, *Client) {
+	t.Helper()
+	client, err := NewClient(&config.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.SeedMarket([]*models.Asset{models.NewEquityAsset("AAPL", "NASDAQ", "Technology", 190)})
+
+	if cfg.Seed == 0 {
+		cfg.Seed = 42
+	}
+	return NewFaultInjector(client, cfg), client
+}
+
+func newTestTrade() *models.Trade {
+	return models.NewTrade(1, "AAPL", models.OrderSideBuy, models.TradeTypeMarket,
+		decimal.NewFromInt(10), decimal.NewFromInt(190), "Test")
+}
+
+func TestParseOutageWindows(t *testing.T) {
+	tests := []struct {
+		value string
+		want  []OutageWindow
+	}{
+		{"", []OutageWindow{}},
+		{"  ", []OutageWindow{}},
+		{"10m-15m", []OutageWindow{{10 * time.Minute, 15 * time.Minute}}},
+		{"10m-15m, 1h-1h5m", []OutageWindow{{10 * time.Minute, 15 * time.Minute}, {time.Hour, time.Hour + 5*time.Minute}}},
+		{"0s-30s", []OutageWindow{{0, 30 * time.Second}}},
+	}
+	for _, tt := range tests {
+		got, err := ParseOutageWindows(tt.value)
+		if err != nil {
+			t.Errorf("ParseOutageWindows(%q): %v", tt.value, err)
+			continue
+		}
+		if len(got) != len(tt.want) || (len(got) > 0 && !reflect.DeepEqual(got, tt.want)) {
+			t.Errorf("ParseOutageWindows(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+
+	for _, value := range []string{"10m", "x-15m", "10m-x", "15m-10m", "10m-10m", "10m-15m,"} {
+		if _, err := ParseOutageWindows(value); err == nil {
+			t.Errorf("ParseOutageWindows(%q) should fail", value)
+		}
+	}
+}
+
+func TestFaultInjectorReject(t *testing.T) {
+	injector, client := newTestInjector(t, FaultConfig{RejectRate: 1})
+	trade := newTestTrade()
+
+	if err := injector.MockPlaceOrder(context.Background(), trade); !errors.Is(err, ErrOrderRejected) {
+		t.Fatalf("got %v, want ErrOrderRejected", err)
+	}
+	if trade.Status != models.TradeStatusRejected || !strings.HasPrefix(trade.AlpacaOrderID, "mock_rejected_") {
+		t.Fatalf("rejected trade has status %s and order ID %q", trade.Status, trade.AlpacaOrderID)
+	}
+
+	// A rejected order never reaches the broker
+	if _, err := client.GetOrder(context.Background(), trade.AlpacaOrderID); !errors.Is(err, ErrOrderNotFound) {
+		t.Fatalf("rejected order lookup: got %v, want ErrOrderNotFound", err)
+	}
+}
+
+func TestFaultInjectorTimeoutThenReconcile(t *testing.T) {
+	injector, _ := newTestInjector(t, FaultConfig{TimeoutRate: 1, Timeout: time.Millisecond})
+	trade := newTestTrade()
+
+	if err := injector.MockPlaceOrder(context.Background(), trade); !errors.Is(err, ErrBrokerTimeout) {
+		t.Fatalf("got %v, want ErrBrokerTimeout", err)
+	}
+
+	// The caller only learns the order ID; the broker filled the order
+	if trade.Status != models.TradeStatusPending || trade.AlpacaOrderID == "" {
+		t.Fatalf("timed out trade has status %s and order ID %q", trade.Status, trade.AlpacaOrderID)
+	}
+	order, err := injector.GetOrder(context.Background(), trade.AlpacaOrderID)
+	if err != nil {
+		t.Fatalf("reconciling timed out order: %v", err)
+	}
+	if order.Status != string(models.TradeStatusFilled) || !order.Price.IsPositive() || order.FilledAt == nil {
+		t.Fatalf("timed out order reconciled as %+v, want filled", order)
+	}
+}
+
+func TestFaultInjectorAckDelay(t *testing.T) {
+	// A delay inside the deadline only slows the acknowledgement down
+	injector, _ := newTestInjector(t, FaultConfig{AckDelayRate: 1, AckDelay: time.Millisecond})
+	trade := newTestTrade()
+	if err := injector.MockPlaceOrder(context.Background(), trade); err != nil {
+		t.Fatalf("delayed acknowledgement: %v", err)
+	}
+	if trade.Status != models.TradeStatusFilled {
+		t.Fatalf("delayed trade has status %s, want filled", trade.Status)
+	}
+
+	// A delay past the deadline times out, but the order still reaches the broker
+	injector, _ = newTestInjector(t, FaultConfig{AckDelayRate: 1, AckDelay: time.Hour})
+	trade = newTestTrade()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if err := injector.MockPlaceOrder(ctx, trade); !errors.Is(err, ErrBrokerTimeout) {
+		t.Fatalf("got %v, want ErrBrokerTimeout", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("late acknowledgement waited %v past the deadline", elapsed)
+	}
+	if trade.Status != models.TradeStatusPending {
+		t.Fatalf("late trade has status %s, want pending", trade.Status)
+	}
+	order, err := injector.GetOrder(context.Background(), trade.AlpacaOrderID)
+	if err != nil || order.Status != string(models.TradeStatusFilled) {
+		t.Fatalf("late order reconciled as %+v, %v; want filled", order, err)
+	}
+}
+
+func TestFaultInjectorDuplicateFill(t *testing.T) {
+	injector, client := newTestInjector(t, FaultConfig{DuplicateFillRate: 1})
+	trade := newTestTrade()
+
+	if err := injector.MockPlaceOrder(context.Background(), trade); !errors.Is(err, ErrDuplicateFill) {
+		t.Fatalf("got %v, want ErrDuplicateFill", err)
+	}
+	if trade.Status != models.TradeStatusFilled {
+		t.Fatalf("duplicate fill left trade %s, want filled", trade.Status)
+	}
+	if len(client.orders) != 1 {
+		t.Fatalf("broker recorded %d orders, want 1", len(client.orders))
+	}
+}
+
+func TestFaultInjectorOutageWindow(t *testing.T) {
+	injector, client := newTestInjector(t, FaultConfig{Outages: []OutageWindow{{0, time.Hour}}})
+	trade := newTestTrade()
+
+	if err := injector.MockPlaceOrder(context.Background(), trade); !errors.Is(err, ErrBrokerUnavailable) {
+		t.Fatalf("got %v, want ErrBrokerUnavailable", err)
+	}
+	if len(client.orders) != 0 {
+		t.Fatalf("order reached the broker during an outage")
+	}
+
+	// Outside the window orders go through
+	injector, _ = newTestInjector(t, FaultConfig{Outages: []OutageWindow{{time.Hour, 2 * time.Hour}}})
+	if err := injector.MockPlaceOrder(context.Background(), newTestTrade()); err != nil {
+		t.Fatalf("order before the outage: %v", err)
+	}
+	if !injector.inOutage(injector.started.Add(90*time.Minute)) || injector.inOutage(injector.started.Add(2*time.Hour)) {
+		t.Fatal("outage window bounds are not start-inclusive and end-exclusive")
+	}
+}
+
+func TestFaultInjectorIsReproducible(t *testing.T) {
+	outcomes := func() []error {
+		injector, _ := newTestInjector(t, FaultConfig{Seed: 7, RejectRate: 0.3, DuplicateFillRate: 0.3})
+		var errs []error
+		for i := 0; i < 50; i++ {
+			errs = append(errs, injector.MockPlaceOrder(context.Background(), newTestTrade()))
+		}
+		return errs
+	}
+
+	first, second := outcomes(), outcomes()
+	rejected := 0
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("order %d: %v, then %v with the same seed", i, first[i], second[i])
+		}
+		if errors.Is(first[i], ErrOrderRejected) {
+			rejected++
+		}
+	}
+	if rejected == 0 || rejected == len(first) {
+		t.Fatalf("%d of %d orders rejected at a 0.3 reject rate", rejected, len(first))
+	}
+}
@@ -0,0 +1,433 @@
+// Package analytics measures how much predictive information persisted
+// signals carry, independent of position sizing and execution.
+package analytics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/MunishMummadi/mock-trade-algorithm/database"
+	"github.com/MunishMummadi/mock-trade-algorithm/models"
+)
+
+// HorizonStats summarizes signal outcomes a fixed number of trading-day bars
+// ahead.
+// Returns are signed by the signal's direction, so a SELL followed by a
+// falling price counts as a positive return.
+type HorizonStats struct {
+	Horizon   int
+	Count     int
+	HitRate   float64 // share of signals with a positive signed return
+	AvgReturn float64 // mean signed return
+
+	// IC is the information coefficient: the Spearman rank correlation of
+	// the signal's signed strength with the raw forward return
+	IC float64
+}
+
+// Stats groups the horizon statistics of one strategy or symbol
+type Stats struct {
+	Key      string
+	Signals  int
+	Horizons []*HorizonStats
+
+	// Decay is the mean signed return at every horizon from 1 to the
+	// longest one requested, showing how quickly the edge fades
+	Decay []float64
+}
+
+// Report is the signal quality report
+type Report struct {
+	Horizons    []int
+	ByStrategy  []*Stats
+	BySymbol    []*Stats
+	GeneratedAt time.Time
+}
+
+// observation is one signal with its forward returns; forward[h-1] is the
+// raw return h bars after the signal, NaN when not yet available
+type observation struct {
+	signal  *models.TradingSignal
+	forward []float64
+}
+
+func (o *observation) direction() float64 {
+	if o.signal.Signal == "SELL" {
+		return -1
+	}
+	return 1
+}
+
+// SignalQuality builds the report for the signals matching filter. Forward
+// returns are measured against trading-day bars built from the market_data
+// snapshots the engine records every trading cycle, so horizons count days
+// however often the engine runs.
+func SignalQuality(db *database.Database, filter database.SignalFilter, horizons []int) (*Report, error) {
+	if len(horizons) == 0 {
+		return nil, fmt.Errorf("at least one horizon is required")
+	}
+
+	signals, prices, err := load(db, filter)
+	if err != nil {
+		return nil, err
+	}
+	return Analyze(signals, prices, horizons), nil
+}
+
+// Outcome is a signal with its signed return a fixed number of bars later
+type Outcome struct {
+	Signal *models.TradingSignal
+	Return float64
+}
+
+// Profitable reports whether the signal's direction was right
+func (o Outcome) Profitable() bool {
+	return o.Return > 0
+}
+
+// Outcomes returns the signed forward return of every matching signal
+// horizon bars later, skipping signals too recent to have one
+func Outcomes(db *database.Database, filter database.SignalFilter, horizon int) ([]Outcome, error) {
+	if horizon <= 0 {
+		return nil, fmt.Errorf("horizon must be positive")
+	}
+
+	signals, prices, err := load(db, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	bars := dailyBars(prices)
+	var outcomes []Outcome
+	for _, signal := range signals {
+		if !isDirectional(signal) {
+			continue
+		}
+		obs := &observation{signal: signal, forward: forwardReturns(signal, bars[signal.Symbol], horizon)}
+		if raw := obs.forward[horizon-1]; !math.IsNaN(raw) {
+			outcomes = append(outcomes, Outcome{Signal: signal, Return: obs.direction() * raw})
+		}
+	}
+	return outcomes, nil
+}
+
+// load fetches the matching signals and each symbol's price snapshots from
+// its earliest signal onwards
+func load(db *database.Database, filter database.SignalFilter) ([]*models.TradingSignal, map[string][]*models.MarketData, error) {
+	signals, err := db.ListTradingSignals(filter)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	earliest := make(map[string]time.Time)
+	for _, signal := range signals {
+		if start, exists := earliest[signal.Symbol]; !exists || signal.CreatedAt.Before(start) {
+			earliest[signal.Symbol] = signal.CreatedAt
+		}
+	}
+
+	prices := make(map[string][]*models.MarketData)
+	for symbol, from := range earliest {
+		data, err := db.GetMarketData(symbol, from, time.Now().Add(time.Second))
+		if err != nil {
+			return nil, nil, err
+		}
+		prices[symbol] = data
+	}
+
+	return signals, prices, nil
+}
+
+// isDirectional reports whether a signal is scored on its symbol's forward
+// return. Multi-leg signals are not: their outcome is the spread between their
+// legs, not the move of the first leg they are recorded under.
+func isDirectional(signal *models.TradingSignal) bool {
+	return (signal.Signal == "BUY" || signal.Signal == "SELL") && signal.Price.IsPositive() &&
+		len(signal.Legs) == 0
+}
+
+// ParseHorizons parses a comma separated list of positive bar counts such
+// as "1,5,20"
+func ParseHorizons(value string) ([]int, error) {
+	var horizons []int
+	for _, part := range strings.Split(value, ",") {
+		if part = strings.TrimSpace(part); part == "" {
+			continue
+		}
+		h, err := strconv.Atoi(part)
+		if err != nil || h <= 0 {
+			return nil, fmt.Errorf("invalid horizon %q", part)
+		}
+		horizons = append(horizons, h)
+	}
+	if len(horizons) == 0 {
+		return nil, fmt.Errorf("at least one horizon is required")
+	}
+	sort.Ints(horizons)
+	return horizons, nil
+}
+
+// Analyze computes the report from signals and per-symbol price snapshots
+// ordered by time, which are bucketed into trading-day bars
+func Analyze(signals []*models.TradingSignal, prices map[string][]*models.MarketData, horizons []int) *Report {
+	maxHorizon := 0
+	for _, h := range horizons {
+		if h > maxHorizon {
+			maxHorizon = h
+		}
+	}
+
+	bars := dailyBars(prices)
+	byStrategy := make(map[string][]*observation)
+	bySymbol := make(map[string][]*observation)
+	for _, signal := range signals {
+		if !isDirectional(signal) {
+			continue
+		}
+
+		obs := &observation{signal: signal, forward: forwardReturns(signal, bars[signal.Symbol], maxHorizon)}
+		byStrategy[signal.Strategy] = append(byStrategy[signal.Strategy], obs)
+		bySymbol[signal.Symbol] = append(bySymbol[signal.Symbol], obs)
+	}
+
+	return &Report{
+		Horizons:    horizons,
+		ByStrategy:  summarize(byStrategy, horizons, maxHorizon),
+		BySymbol:    summarize(bySymbol, horizons, maxHorizon),
+		GeneratedAt: time.Now(),
+	}
+}
+
+// dailyBars buckets each symbol's snapshots into one bar per trading day,
+// closing at the day's last snapshot. Snapshots are only recorded while a
+// symbol's market is open, so every day with one is a trading day. The
+// latest day is left out until a later snapshot shows it has closed.
+func dailyBars(prices map[string][]*models.MarketData) map[string][]*models.MarketData {
+	bars := make(map[string][]*models.MarketData, len(prices))
+	for symbol, data := range prices {
+		for i := 0; i+1 < len(data); i++ {
+			if tradingDay(data[i].Timestamp) != tradingDay(data[i+1].Timestamp) {
+				bars[symbol] = append(bars[symbol], data[i])
+			}
+		}
+	}
+	return bars
+}
+
+// tradingDay is the local calendar day of t, which the market calendars
+// also work in
+func tradingDay(t time.Time) time.Time {
+	year, month, day := t.Local().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.Local)
+}
+
+// forwardReturns returns the raw return at the close of each of the
+// 1..maxHorizon trading days after the signal's day, measured from the
+// signal's price
+func forwardReturns(signal *models.TradingSignal, bars []*models.MarketData, maxHorizon int) []float64 {
+	forward := make([]float64, maxHorizon)
+	day := tradingDay(signal.CreatedAt)
+	start := sort.Search(len(bars), func(i int) bool {
+		return tradingDay(bars[i].Timestamp).After(day)
+	})
+
+	entry := signal.Price.InexactFloat64()
+	for h := 1; h <= maxHorizon; h++ {
+		if i := start + h - 1; i < len(bars) {
+			forward[h-1] = bars[i].Price.InexactFloat64()/entry - 1
+		} else {
+			forward[h-1] = math.NaN()
+		}
+	}
+	return forward
+}
+
+func summarize(groups map[string][]*observation, horizons []int, maxHorizon int) []*Stats {
+	keys := make([]string, 0, len(groups))
+	for key := range groups {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	stats := make([]*Stats, 0, len(keys))
+	for _, key := range keys {
+		group := groups[key]
+		s := &Stats{Key: key, Signals: len(group), Decay: make([]float64, maxHorizon)}
+
+		for h := 1; h <= maxHorizon; h++ {
+			s.Decay[h-1] = horizonStats(group, h).AvgReturn
+		}
+		for _, h := range horizons {
+			s.Horizons = append(s.Horizons, horizonStats(group, h))
+		}
+		stats = append(stats, s)
+	}
+	return stats
+}
+
+func horizonStats(group []*observation, horizon int) *HorizonStats {
+	stats := &HorizonStats{Horizon: horizon}
+	var scores, returns []float64
+	hits := 0
+	total := 0.0
+
+	for _, obs := range group {
+		raw := obs.forward[horizon-1]
+		if math.IsNaN(raw) {
+			continue
+		}
+		signed := obs.direction() * raw
+		if signed > 0 {
+			hits++
+		}
+		total += signed
+		scores = append(scores, obs.direction()*obs.signal.Strength)
+		returns = append(returns, raw)
+	}
+
+	stats.Count = len(returns)
+	if stats.Count == 0 {
+		stats.AvgReturn = math.NaN()
+		stats.HitRate = math.NaN()
+		stats.IC = math.NaN()
+		return stats
+	}
+	stats.HitRate = float64(hits) / float64(stats.Count)
+	stats.AvgReturn = total / float64(stats.Count)
+	stats.IC = spearman(scores, returns)
+	return stats
+}
+
+// spearman returns the rank correlation of x and y, NaN if either is constant
+func spearman(x, y []float64) float64 {
+	if len(x) < 2 {
+		return math.NaN()
+	}
+	return pearson(ranks(x), ranks(y))
+}
+
+// ranks assigns 1-based ranks, averaging ties
+func ranks(values []float64) []float64 {
+	order := make([]int, len(values))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return values[order[a]] < values[order[b]]
+	})
+
+	ranked := make([]float64, len(values))
+	for i := 0; i < len(order); {
+		j := i
+		for j+1 < len(order) && values[order[j+1]] == values[order[i]] {
+			j++
+		}
+		rank := float64(i+j)/2 + 1
+		for k := i; k <= j; k++ {
+			ranked[order[k]] = rank
+		}
+		i = j + 1
+	}
+	return ranked
+}
+
+func pearson(x, y []float64) float64 {
+	n := float64(len(x))
+	var meanX, meanY float64
+	for i := range x {
+		meanX += x[i]
+		meanY += y[i]
+	}
+	meanX /= n
+	meanY /= n
+
+	var cov, varX, varY float64
+	for i := range x {
+		dx, dy := x[i]-meanX, y[i]-meanY
+		cov += dx * dy
+		varX += dx * dx
+		varY += dy * dy
+	}
+	if varX == 0 || varY == 0 {
+		return math.NaN()
+	}
+	return cov / math.Sqrt(varX*varY)
+}
+
+// Print writes the report as plain text tables
+func (r *Report) Print(w io.Writer) error {
+	fmt.Fprintf(w, "Signal quality report (generated %s)\n", r.GeneratedAt.Format(time.RFC3339))
+
+	for _, section := range []struct {
+		title string
+		stats []*Stats
+	}{
+		{"By strategy", r.ByStrategy},
+		{"By symbol", r.BySymbol},
+	} {
+		fmt.Fprintf(w, "\n%s\n", section.title)
+
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		header := []string{"name", "signals"}
+		for _, h := range r.Horizons {
+			header = append(header, fmt.Sprintf("n@%d", h), fmt.Sprintf("hit@%d", h),
+				fmt.Sprintf("avg@%d", h), fmt.Sprintf("ic@%d", h))
+		}
+		header = append(header, "decay")
+		fmt.Fprintln(tw, strings.Join(header, "\t"))
+
+		for _, s := range section.stats {
+			row := []string{s.Key, fmt.Sprint(s.Signals)}
+			for _, h := range s.Horizons {
+				row = append(row, fmt.Sprint(h.Count), rate(h.HitRate), percent(h.AvgReturn), number(h.IC))
+			}
+			row = append(row, decayCurve(s.Decay))
+			fmt.Fprintln(tw, strings.Join(row, "\t"))
+		}
+
+		if err := tw.Flush(); err != nil {
+			return fmt.Errorf("failed to write report: %w", err)
+		}
+	}
+	return nil
+}
+
+func rate(v float64) string {
+	if math.IsNaN(v) {
+		return "-"
+	}
+	return fmt.Sprintf("%.0f%%", v*100)
+}
+
+func percent(v float64) string {
+	if math.IsNaN(v) {
+		return "-"
+	}
+	return fmt.Sprintf("%+.2f%%", v*100)
+}
+
+func number(v float64) string {
+	if math.IsNaN(v) {
+		return "-"
+	}
+	return fmt.Sprintf("%+.3f", v)
+}
+
+// decayCurve renders the mean signed return per horizon in basis points
+func decayCurve(decay []float64) string {
+	points := make([]string, len(decay))
+	for i, v := range decay {
+		if math.IsNaN(v) {
+			points[i] = "-"
+			continue
+		}
+		points[i] = fmt.Sprintf("%.0f", v*10000)
+	}
+	return strings.Join(points, " ") + " bp"
+}
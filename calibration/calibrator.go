@@ -0,0 +1,174 @@
+package calibration
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/MunishMummadi/mock-trade-algorithm/analytics"
+	"github.com/MunishMummadi/mock-trade-algorithm/config"
+	"github.com/MunishMummadi/mock-trade-algorithm/database"
+	"github.com/MunishMummadi/mock-trade-algorithm/models"
+)
+
+// Calibration methods
+const (
+	MethodIsotonic = "isotonic"
+	MethodPlatt    = "platt"
+)
+
+// Config controls how curves are fitted
+type Config struct {
+	Method      string        // isotonic or platt
+	Horizon     int           // trading-day bars after a signal at which profitability is judged
+	MinSamples  int           // strategies with fewer outcomes stay uncalibrated
+	Lookback    time.Duration // how far back outcomes are taken from
+	RefitPeriod time.Duration // how often curves are refitted
+}
+
+// ConfigFromConfig builds the calibration settings from application config
+func ConfigFromConfig(cfg *config.Config) Config {
+	return Config{
+		Method:      cfg.CalibrationMethod,
+		Horizon:     int(cfg.CalibrationBars),
+		MinSamples:  int(cfg.CalibrationMinSamples),
+		Lookback:    cfg.CalibrationLookback,
+		RefitPeriod: cfg.CalibrationRefitPeriod,
+	}
+}
+
+// Calibrator holds one fitted curve per strategy and rewrites signal
+// strengths through them
+type Calibrator struct {
+	db     *database.Database
+	config Config
+
+	mu        sync.RWMutex
+	curves    map[string]Curve
+	fittedAt  time.Time
+	refitting bool
+	refits    sync.WaitGroup
+}
+
+func NewCalibrator(db *database.Database, cfg Config) (*Calibrator, error) {
+	switch cfg.Method {
+	case MethodIsotonic, MethodPlatt:
+	default:
+		return nil, fmt.Errorf("unknown calibration method %q", cfg.Method)
+	}
+
+	return &Calibrator{
+		db:     db,
+		config: cfg,
+		curves: make(map[string]Curve),
+	}, nil
+}
+
+// Fit refits every strategy's curve from the outcomes of its persisted
+// signals. Raw strengths are used so earlier calibration doesn't feed back
+// into the fit.
+func (c *Calibrator) Fit(now time.Time) error {
+	outcomes, err := analytics.Outcomes(c.db, database.SignalFilter{From: now.Add(-c.config.Lookback)}, c.config.Horizon)
+	if err != nil {
+		return fmt.Errorf("failed to load signal outcomes: %w", err)
+	}
+
+	strengths := make(map[string][]float64)
+	profitable := make(map[string][]bool)
+	for _, outcome := range outcomes {
+		strategy := outcome.Signal.Strategy
+		strengths[strategy] = append(strengths[strategy], outcome.Signal.RawStrength)
+		profitable[strategy] = append(profitable[strategy], outcome.Profitable())
+	}
+
+	curves := make(map[string]Curve)
+	for strategy, samples := range strengths {
+		if len(samples) < c.config.MinSamples {
+			continue
+		}
+
+		var curve Curve
+		if c.config.Method == MethodPlatt {
+			curve = FitPlatt(samples, profitable[strategy])
+		} else {
+			curve = FitIsotonic(samples, profitable[strategy])
+		}
+		curves[strategy] = curve
+
+		log.Printf("Calibrated %s on %d signals: strength 0.5 -> %.2f, 1.0 -> %.2f",
+			strategy, len(samples), curve.Probability(0.5), curve.Probability(1.0))
+	}
+
+	c.mu.Lock()
+	c.curves = curves
+	c.fittedAt = now
+	c.mu.Unlock()
+	return nil
+}
+
+// MaybeRefit starts refitting the curves in the background when the refit
+// period has passed. Signals keep using the current curves until the new
+// ones are swapped in; a failed refit is logged and retried a refit period
+// later.
+func (c *Calibrator) MaybeRefit(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.refitting || now.Sub(c.fittedAt) < c.config.RefitPeriod {
+		return
+	}
+	c.refitting = true
+	c.fittedAt = now
+
+	c.refits.Add(1)
+	go func() {
+		defer c.refits.Done()
+		if err := c.Fit(now); err != nil {
+			log.Printf("Warning: failed to recalibrate signal strengths: %v", err)
+		}
+
+		c.mu.Lock()
+		c.refitting = false
+		c.mu.Unlock()
+	}()
+}
+
+// Wait blocks until a refit running in the background has finished
+func (c *Calibrator) Wait() {
+	c.refits.Wait()
+}
+
+// Calibrate calibrates a signal that is not compared with others
+func (c *Calibrator) Calibrate(signal *models.TradingSignal) {
+	c.CalibrateAll([]*models.TradingSignal{signal})
+}
+
+// CalibrateAll replaces the strengths of signals that are weighed against
+// each other, such as the votes on one symbol, with calibrated
+// probabilities, keeping the originals in RawStrength. Probabilities and raw
+// strengths are on different scales, so unless every signal's strategy has
+// enough history to be calibrated all of them keep their raw strength.
+func (c *Calibrator) CalibrateAll(signals []*models.TradingSignal) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	curves := make([]Curve, len(signals))
+	complete := true
+	for i, signal := range signals {
+		if signal.RawStrength == 0 {
+			signal.RawStrength = signal.Strength
+		}
+		curve, exists := c.curves[signal.Strategy]
+		if !exists {
+			complete = false
+		}
+		curves[i] = curve
+	}
+	if !complete {
+		return
+	}
+
+	for i, signal := range signals {
+		signal.Strength = curves[i].Probability(signal.RawStrength)
+	}
+}
@@ -0,0 +1,166 @@
+// Package calibration maps each strategy's raw signal strength to the
+// empirical probability that the signal is followed by a profitable move.
+package calibration
+
+import (
+	"math"
+	"sort"
+)
+
+// Curve maps a raw strength to a probability between 0 and 1
+type Curve interface {
+	Probability(strength float64) float64
+}
+
+// IsotonicCurve is a non-decreasing step function fitted with the
+// pool-adjacent-violators algorithm. Between block centers the probability
+// is interpolated linearly; outside them it is held flat.
+type IsotonicCurve struct {
+	Strengths     []float64 // block centers, increasing
+	Probabilities []float64 // smoothed hit rate of each block, non-decreasing
+}
+
+// FitIsotonic fits an isotonic curve to strengths and whether each signal
+// was profitable
+func FitIsotonic(strengths []float64, profitable []bool) *IsotonicCurve {
+	type block struct {
+		sumStrength float64
+		hits        float64
+		count       float64
+	}
+
+	order := make([]int, len(strengths))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return strengths[order[a]] < strengths[order[b]]
+	})
+
+	var blocks []block
+	for _, i := range order {
+		b := block{sumStrength: strengths[i], count: 1}
+		if profitable[i] {
+			b.hits = 1
+		}
+		blocks = append(blocks, b)
+
+		// Merge backwards while the hit rates decrease
+		for len(blocks) > 1 {
+			last, prev := blocks[len(blocks)-1], blocks[len(blocks)-2]
+			if prev.hits/prev.count <= last.hits/last.count {
+				break
+			}
+			blocks = blocks[:len(blocks)-2]
+			blocks = append(blocks, block{
+				sumStrength: prev.sumStrength + last.sumStrength,
+				hits:        prev.hits + last.hits,
+				count:       prev.count + last.count,
+			})
+		}
+	}
+
+	// Small blocks at the ends would otherwise report certainty, so block
+	// rates get add-one smoothing, with a running max to stay monotone
+	curve := &IsotonicCurve{}
+	floor := 0.0
+	for _, b := range blocks {
+		probability := math.Max(floor, (b.hits+1)/(b.count+2))
+		floor = probability
+		curve.Strengths = append(curve.Strengths, b.sumStrength/b.count)
+		curve.Probabilities = append(curve.Probabilities, probability)
+	}
+	return curve
+}
+
+func (c *IsotonicCurve) Probability(strength float64) float64 {
+	n := len(c.Strengths)
+	if n == 0 {
+		return strength
+	}
+	if strength <= c.Strengths[0] {
+		return c.Probabilities[0]
+	}
+	if strength >= c.Strengths[n-1] {
+		return c.Probabilities[n-1]
+	}
+
+	i := sort.SearchFloat64s(c.Strengths, strength)
+	x0, x1 := c.Strengths[i-1], c.Strengths[i]
+	y0, y1 := c.Probabilities[i-1], c.Probabilities[i]
+	if x1 == x0 {
+		return y1
+	}
+	return y0 + (y1-y0)*(strength-x0)/(x1-x0)
+}
+
+// PlattCurve is a logistic curve 1 / (1 + exp(-(A*strength + B)))
+type PlattCurve struct {
+	A float64
+	B float64
+}
+
+// FitPlatt fits a logistic curve by Newton's method on the log loss, using
+// Platt's smoothed targets so a perfectly separable sample doesn't push the
+// slope to infinity
+func FitPlatt(strengths []float64, profitable []bool) *PlattCurve {
+	var positives, negatives float64
+	for _, p := range profitable {
+		if p {
+			positives++
+		} else {
+			negatives++
+		}
+	}
+	hiTarget := (positives + 1) / (positives + 2)
+	loTarget := 1 / (negatives + 2)
+
+	targets := make([]float64, len(strengths))
+	for i, p := range profitable {
+		targets[i] = loTarget
+		if p {
+			targets[i] = hiTarget
+		}
+	}
+
+	// Start from the base rate
+	curve := &PlattCurve{B: math.Log((positives + 1) / (negatives + 1))}
+
+	for iteration := 0; iteration < 100; iteration++ {
+		// Gradient and Hessian of the log loss in (A, B)
+		var gA, gB, hAA, hAB, hBB float64
+		for i, s := range strengths {
+			p := curve.Probability(s)
+			diff := p - targets[i]
+			weight := math.Max(p*(1-p), 1e-12)
+
+			gA += diff * s
+			gB += diff
+			hAA += weight * s * s
+			hAB += weight * s
+			hBB += weight
+		}
+
+		// Small ridge keeps the Hessian invertible for constant strengths
+		hAA += 1e-9
+		hBB += 1e-9
+		det := hAA*hBB - hAB*hAB
+		if det == 0 {
+			break
+		}
+
+		dA := (hBB*gA - hAB*gB) / det
+		dB := (hAA*gB - hAB*gA) / det
+		curve.A -= dA
+		curve.B -= dB
+
+		if math.Abs(dA) < 1e-9 && math.Abs(dB) < 1e-9 {
+			break
+		}
+	}
+	return curve
+}
+
+func (c *PlattCurve) Probability(strength float64) float64 {
+	return 1 / (1 + math.Exp(-(c.A*strength + c.B)))
+}
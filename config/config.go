@@ -5,6 +5,7 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -30,6 +31,100 @@ type Config struct {
 	RiskPercentage  float64
 	TradingEnabled  bool
 
+	// Strategy Configuration
+	EnabledStrategies string  // comma separated strategy names, empty enables all
+	RuleStrategyDir   string  // directory of JSON rule strategies, empty for none
+	MACDPeriods       []int64 // fast EMA, slow EMA and signal line periods
+	EMAPeriods        []int64 // short and long EMA periods
+	DonchianPeriod    int64   // channel length in bars
+
+	// Market Regime Configuration
+	RegimeEnabled             bool
+	RegimeADXPeriod           int64
+	RegimeTrendADX            float64 // ADX at or above which a symbol votes trend
+	RegimeRangeADX            float64 // ADX at or below which a symbol votes range
+	RegimeVolatilityWindow    int64   // returns in the recent volatility estimate
+	RegimeHighVolatilityRatio float64 // recent over long-run volatility that counts as high volatility
+	RegimeHurstTrend          float64
+	RegimeHurstRange          float64
+	RegimeConfirm             int64  // consecutive readings needed to change regime
+	StrategyStyles            string // comma separated "strategy=style" pairs
+	RegimeWeights             string // comma separated "regime:style=weight" pairs
+
+	// Candlestick Pattern Configuration
+	PatternDojiBody       float64 // largest doji body, as a fraction of the range
+	PatternLongBody       float64 // smallest long candle body, as a fraction of the range
+	PatternShadowRatio    float64 // smallest long shadow of a hammer or shooting star, in bodies
+	PatternOppositeShadow float64 // largest opposite shadow of a hammer or shooting star, as a fraction of the range
+	PatternStarBody       float64 // largest star body, as a fraction of the first body
+	PatternPriceTolerance float64 // relative slack when comparing prices
+	PatternTrendPeriod    int64   // bars of context before a pattern
+	PatternMinMove        float64 // smallest prior move against the pattern's direction
+	PatternVolumeRatio    float64 // volume over the prior average that strengthens a signal
+
+	// Multi-Timeframe Configuration
+	MultiTimeframeStrategies string // semicolon separated wrapper specs, empty for none
+
+	// Strategy Isolation Configuration
+	StrategyTimeout     time.Duration // deadline of a single strategy call
+	StrategyMaxFailures int64         // consecutive failures before a strategy is quarantined
+	StrategyQuarantine  time.Duration // how long a quarantined strategy is skipped
+
+	// External Strategy Configuration
+	ExternalStrategies           string        // semicolon separated commands of external strategy processes
+	ExternalStrategyTimeout      time.Duration // limit on each signal request
+	ExternalStrategyStartTimeout time.Duration // limit on the startup handshake
+
+	// Shadow Mode Configuration
+	ShadowStrategies     string  // comma separated strategies run in shadow mode only
+	ShadowInitialBalance float64 // starting cash of each shadow portfolio
+	ShadowSlippage       float64 // fraction of the price lost on each shadow fill
+
+	// Signal Aggregation Configuration
+	AggregationPolicy            string // majority, weighted, unanimous or any_strong
+	AggregationOverrides         string // comma separated "symbol=policy" pairs; symbols must be in the watchlist
+	AggregationMinVotes          int64
+	AggregationWeightedThreshold float64
+	AggregationStrongThreshold   float64
+	StrategyWeights              string // comma separated "strategy=weight" pairs; names ignore case
+
+	// Adaptive Strategy Weighting Configuration
+	AdaptiveHorizon      time.Duration // time after a signal at which its outcome is scored
+	AdaptiveLearningRate float64
+	AdaptiveDecay        float64 // smoothing factor of the performance averages
+	AdaptiveMinWeight    float64
+	AdaptiveMaxWeight    float64
+
+	// Strength Calibration Configuration
+	CalibrationMethod      string // isotonic or platt
+	CalibrationBars        int64  // trading-day bars after a signal at which profitability is judged
+	CalibrationMinSamples  int64
+	CalibrationLookback    time.Duration
+	CalibrationRefitPeriod time.Duration
+
+	// Exposure Configuration
+	MaxSectorExposure float64 // fraction of total portfolio value
+
+	// Mock Broker Fault Injection Configuration
+	BrokerFaultSeed         int64
+	BrokerMinLatency        time.Duration
+	BrokerMaxLatency        time.Duration
+	BrokerRejectRate        float64
+	BrokerTimeoutRate       float64
+	BrokerTimeout           time.Duration
+	BrokerAckDelayRate      float64
+	BrokerAckDelay          time.Duration
+	BrokerDuplicateFillRate float64
+	BrokerOutages           string        // comma separated "start-end" offsets, e.g. "10m-15m"
+	OrderAckTimeout         time.Duration // how long the engine waits for an order's acknowledgement
+
+	// Market Data Configuration
+	BarAdjustment        string // none, split or all (splits and dividends)
+	CorporateActionsFile string // JSON file of splits and dividends loaded at startup, empty for none
+
+	// Analytics Configuration
+	SignalReportBars string // comma separated forward horizons in trading-day bars, e.g. "1,5,20"
+
 	// Performance Configuration
 	RefreshInterval time.Duration
 }
@@ -60,6 +155,100 @@ func Load() (*Config, error) {
 		RiskPercentage:  getEnvFloat("RISK_PERCENTAGE", 0.02),
 		TradingEnabled:  getEnvBool("TRADING_ENABLED", true),
 
+		// Strategy defaults
+		EnabledStrategies: getEnv("ENABLED_STRATEGIES", ""),
+		RuleStrategyDir:   getEnv("RULE_STRATEGY_DIR", ""),
+		MACDPeriods:       getEnvInts("MACD_PERIODS", []int64{12, 26, 9}),
+		EMAPeriods:        getEnvInts("EMA_PERIODS", []int64{12, 26}),
+		DonchianPeriod:    getEnvInt("DONCHIAN_PERIOD", 20),
+
+		// Market regime defaults
+		RegimeEnabled:             getEnvBool("REGIME_ENABLED", true),
+		RegimeADXPeriod:           getEnvInt("REGIME_ADX_PERIOD", 14),
+		RegimeTrendADX:            getEnvFloat("REGIME_TREND_ADX", 25),
+		RegimeRangeADX:            getEnvFloat("REGIME_RANGE_ADX", 20),
+		RegimeVolatilityWindow:    getEnvInt("REGIME_VOLATILITY_WINDOW", 20),
+		RegimeHighVolatilityRatio: getEnvFloat("REGIME_HIGH_VOLATILITY_RATIO", 1.5),
+		RegimeHurstTrend:          getEnvFloat("REGIME_HURST_TREND", 0.6),
+		RegimeHurstRange:          getEnvFloat("REGIME_HURST_RANGE", 0.4),
+		RegimeConfirm:             getEnvInt("REGIME_CONFIRM", 3),
+		StrategyStyles:            getEnv("STRATEGY_STYLES", ""),
+		RegimeWeights:             getEnv("REGIME_WEIGHTS", ""),
+
+		// Candlestick pattern defaults
+		PatternDojiBody:       getEnvFloat("PATTERN_DOJI_BODY", 0.1),
+		PatternLongBody:       getEnvFloat("PATTERN_LONG_BODY", 0.6),
+		PatternShadowRatio:    getEnvFloat("PATTERN_SHADOW_RATIO", 2.0),
+		PatternOppositeShadow: getEnvFloat("PATTERN_OPPOSITE_SHADOW", 0.15),
+		PatternStarBody:       getEnvFloat("PATTERN_STAR_BODY", 0.3),
+		PatternPriceTolerance: getEnvFloat("PATTERN_PRICE_TOLERANCE", 0.001),
+		PatternTrendPeriod:    getEnvInt("PATTERN_TREND_PERIOD", 10),
+		PatternMinMove:        getEnvFloat("PATTERN_MIN_MOVE", 0.03),
+		PatternVolumeRatio:    getEnvFloat("PATTERN_VOLUME_RATIO", 1.5),
+
+		// Multi-timeframe defaults
+		MultiTimeframeStrategies: getEnv("MULTI_TIMEFRAME_STRATEGIES", ""),
+
+		// Strategy isolation defaults
+		StrategyTimeout:     getEnvDuration("STRATEGY_TIMEOUT", 5*time.Second),
+		StrategyMaxFailures: getEnvInt("STRATEGY_MAX_FAILURES", 3),
+		StrategyQuarantine:  getEnvDuration("STRATEGY_QUARANTINE", 15*time.Minute),
+
+		// External strategy defaults
+		ExternalStrategies:           getEnv("EXTERNAL_STRATEGIES", ""),
+		ExternalStrategyTimeout:      getEnvDuration("EXTERNAL_STRATEGY_TIMEOUT", 5*time.Second),
+		ExternalStrategyStartTimeout: getEnvDuration("EXTERNAL_STRATEGY_START_TIMEOUT", 30*time.Second),
+
+		// Shadow mode defaults
+		ShadowStrategies:     getEnv("SHADOW_STRATEGIES", ""),
+		ShadowInitialBalance: getEnvFloat("SHADOW_INITIAL_BALANCE", 100000.0),
+		ShadowSlippage:       getEnvFloat("SHADOW_SLIPPAGE", 0.002),
+
+		// Signal aggregation defaults
+		AggregationPolicy:            getEnv("AGGREGATION_POLICY", "majority"),
+		AggregationOverrides:         getEnv("AGGREGATION_OVERRIDES", ""),
+		AggregationMinVotes:          getEnvInt("AGGREGATION_MIN_VOTES", 2),
+		AggregationWeightedThreshold: getEnvFloat("AGGREGATION_WEIGHTED_THRESHOLD", 0.3),
+		AggregationStrongThreshold:   getEnvFloat("AGGREGATION_STRONG_THRESHOLD", 0.9),
+		StrategyWeights:              getEnv("STRATEGY_WEIGHTS", ""),
+
+		// Adaptive strategy weighting defaults
+		AdaptiveHorizon:      getEnvDuration("ADAPTIVE_HORIZON", 30*time.Minute),
+		AdaptiveLearningRate: getEnvFloat("ADAPTIVE_LEARNING_RATE", 0.1),
+		AdaptiveDecay:        getEnvFloat("ADAPTIVE_DECAY", 0.05),
+		AdaptiveMinWeight:    getEnvFloat("ADAPTIVE_MIN_WEIGHT", 0.1),
+		AdaptiveMaxWeight:    getEnvFloat("ADAPTIVE_MAX_WEIGHT", 3.0),
+
+		// Strength calibration defaults
+		CalibrationMethod:      getEnv("CALIBRATION_METHOD", "isotonic"),
+		CalibrationBars:        getEnvInt("CALIBRATION_BARS", 5),
+		CalibrationMinSamples:  getEnvInt("CALIBRATION_MIN_SAMPLES", 50),
+		CalibrationLookback:    getEnvDuration("CALIBRATION_LOOKBACK", 30*24*time.Hour),
+		CalibrationRefitPeriod: getEnvDuration("CALIBRATION_REFIT_PERIOD", time.Hour),
+
+		// Exposure defaults
+		MaxSectorExposure: getEnvFloat("MAX_SECTOR_EXPOSURE", 0.4),
+
+		// Mock broker fault injection defaults
+		BrokerFaultSeed:         getEnvInt("BROKER_FAULT_SEED", 0),
+		BrokerMinLatency:        getEnvDuration("BROKER_MIN_LATENCY", 50*time.Millisecond),
+		BrokerMaxLatency:        getEnvDuration("BROKER_MAX_LATENCY", 250*time.Millisecond),
+		BrokerRejectRate:        getEnvFloat("BROKER_REJECT_RATE", 0.01),
+		BrokerTimeoutRate:       getEnvFloat("BROKER_TIMEOUT_RATE", 0),
+		BrokerTimeout:           getEnvDuration("BROKER_TIMEOUT", 5*time.Second),
+		BrokerAckDelayRate:      getEnvFloat("BROKER_ACK_DELAY_RATE", 0),
+		BrokerAckDelay:          getEnvDuration("BROKER_ACK_DELAY", 2*time.Second),
+		BrokerDuplicateFillRate: getEnvFloat("BROKER_DUPLICATE_FILL_RATE", 0),
+		BrokerOutages:           getEnv("BROKER_OUTAGES", ""),
+		OrderAckTimeout:         getEnvDuration("ORDER_ACK_TIMEOUT", time.Second),
+
+		// Market data defaults
+		BarAdjustment:        getEnv("BAR_ADJUSTMENT", "none"),
+		CorporateActionsFile: getEnv("CORPORATE_ACTIONS_FILE", ""),
+
+		// Analytics defaults
+		SignalReportBars: getEnv("SIGNAL_REPORT_BARS", "1,5,20"),
+
 		// Performance defaults
 		RefreshInterval: getEnvDuration("REFRESH_INTERVAL", 5*time.Second),
 	}
@@ -84,6 +273,105 @@ func (c *Config) validate() error {
 	if c.RiskPercentage <= 0 || c.RiskPercentage > 1 {
 		return fmt.Errorf("RISK_PERCENTAGE must be between 0 and 1")
 	}
+	if len(c.MACDPeriods) != 3 || c.MACDPeriods[0] < 1 || c.MACDPeriods[2] < 1 || c.MACDPeriods[0] >= c.MACDPeriods[1] {
+		return fmt.Errorf("MACD_PERIODS must be fast,slow,signal with 1 <= fast < slow and signal >= 1")
+	}
+	if len(c.EMAPeriods) != 2 || c.EMAPeriods[0] < 1 || c.EMAPeriods[0] >= c.EMAPeriods[1] {
+		return fmt.Errorf("EMA_PERIODS must be short,long with 1 <= short < long")
+	}
+	if c.DonchianPeriod < 1 {
+		return fmt.Errorf("DONCHIAN_PERIOD must be at least 1")
+	}
+	if c.AggregationMinVotes < 1 {
+		return fmt.Errorf("AGGREGATION_MIN_VOTES must be at least 1")
+	}
+	if c.AggregationWeightedThreshold < 0 || c.AggregationWeightedThreshold > 1 {
+		return fmt.Errorf("AGGREGATION_WEIGHTED_THRESHOLD must be between 0 and 1")
+	}
+	if c.AggregationStrongThreshold < 0 || c.AggregationStrongThreshold > 1 {
+		return fmt.Errorf("AGGREGATION_STRONG_THRESHOLD must be between 0 and 1")
+	}
+	if c.AdaptiveLearningRate <= 0 || c.AdaptiveLearningRate >= 1 {
+		return fmt.Errorf("ADAPTIVE_LEARNING_RATE must be between 0 and 1, exclusive")
+	}
+	if c.AdaptiveDecay <= 0 || c.AdaptiveDecay > 1 {
+		return fmt.Errorf("ADAPTIVE_DECAY must be between 0 and 1")
+	}
+	if c.AdaptiveMinWeight < 0 || c.AdaptiveMaxWeight < c.AdaptiveMinWeight {
+		return fmt.Errorf("ADAPTIVE_MIN_WEIGHT must not be negative or above ADAPTIVE_MAX_WEIGHT")
+	}
+	if c.CalibrationBars < 1 || c.CalibrationMinSamples < 1 {
+		return fmt.Errorf("CALIBRATION_BARS and CALIBRATION_MIN_SAMPLES must be at least 1")
+	}
+	if c.StrategyTimeout <= 0 || c.StrategyQuarantine <= 0 {
+		return fmt.Errorf("STRATEGY_TIMEOUT and STRATEGY_QUARANTINE must be positive")
+	}
+	if c.StrategyMaxFailures < 1 {
+		return fmt.Errorf("STRATEGY_MAX_FAILURES must be at least 1")
+	}
+	if c.ExternalStrategyTimeout <= 0 || c.ExternalStrategyStartTimeout <= 0 {
+		return fmt.Errorf("EXTERNAL_STRATEGY_TIMEOUT and EXTERNAL_STRATEGY_START_TIMEOUT must be positive")
+	}
+	if c.RegimeADXPeriod < 1 || c.RegimeVolatilityWindow < 2 || c.RegimeConfirm < 1 {
+		return fmt.Errorf("REGIME_ADX_PERIOD and REGIME_CONFIRM must be at least 1 and REGIME_VOLATILITY_WINDOW at least 2")
+	}
+	if c.RegimeRangeADX > c.RegimeTrendADX {
+		return fmt.Errorf("REGIME_RANGE_ADX must not exceed REGIME_TREND_ADX")
+	}
+	if c.RegimeHurstRange > c.RegimeHurstTrend {
+		return fmt.Errorf("REGIME_HURST_RANGE must not exceed REGIME_HURST_TREND")
+	}
+	if c.RegimeHighVolatilityRatio <= 1 {
+		return fmt.Errorf("REGIME_HIGH_VOLATILITY_RATIO must be greater than 1")
+	}
+	if c.PatternDojiBody <= 0 || c.PatternLongBody > 1 || c.PatternDojiBody >= c.PatternLongBody {
+		return fmt.Errorf("PATTERN_DOJI_BODY and PATTERN_LONG_BODY must satisfy 0 < doji < long <= 1")
+	}
+	if c.PatternShadowRatio <= 0 || c.PatternStarBody <= 0 {
+		return fmt.Errorf("PATTERN_SHADOW_RATIO and PATTERN_STAR_BODY must be positive")
+	}
+	if c.PatternOppositeShadow < 0 || c.PatternOppositeShadow >= 1 {
+		return fmt.Errorf("PATTERN_OPPOSITE_SHADOW must be between 0 and 1")
+	}
+	if c.PatternPriceTolerance < 0 || c.PatternMinMove < 0 {
+		return fmt.Errorf("PATTERN_PRICE_TOLERANCE and PATTERN_MIN_MOVE must not be negative")
+	}
+	if c.PatternTrendPeriod < 1 {
+		return fmt.Errorf("PATTERN_TREND_PERIOD must be at least 1")
+	}
+	if c.PatternVolumeRatio <= 0 {
+		return fmt.Errorf("PATTERN_VOLUME_RATIO must be positive")
+	}
+	if c.ShadowInitialBalance <= 0 {
+		return fmt.Errorf("SHADOW_INITIAL_BALANCE must be positive")
+	}
+	if c.ShadowSlippage < 0 || c.ShadowSlippage >= 1 {
+		return fmt.Errorf("SHADOW_SLIPPAGE must be between 0 and 1")
+	}
+	if c.MaxSectorExposure <= 0 || c.MaxSectorExposure > 1 {
+		return fmt.Errorf("MAX_SECTOR_EXPOSURE must be between 0 and 1")
+	}
+	for name, rate := range map[string]float64{
+		"BROKER_REJECT_RATE":         c.BrokerRejectRate,
+		"BROKER_TIMEOUT_RATE":        c.BrokerTimeoutRate,
+		"BROKER_ACK_DELAY_RATE":      c.BrokerAckDelayRate,
+		"BROKER_DUPLICATE_FILL_RATE": c.BrokerDuplicateFillRate,
+	} {
+		if rate < 0 || rate > 1 {
+			return fmt.Errorf("%s must be between 0 and 1", name)
+		}
+	}
+	if c.OrderAckTimeout <= 0 {
+		return fmt.Errorf("ORDER_ACK_TIMEOUT must be positive")
+	}
+	if c.BrokerMaxLatency < c.BrokerMinLatency {
+		return fmt.Errorf("BROKER_MAX_LATENCY must not be less than BROKER_MIN_LATENCY")
+	}
+	switch c.BarAdjustment {
+	case "none", "split", "all":
+	default:
+		return fmt.Errorf("BAR_ADJUSTMENT must be one of none, split or all")
+	}
 	return nil
 }
 
@@ -103,6 +391,33 @@ func getEnvFloat(key string, defaultValue float64) float64 {
 	return defaultValue
 }
 
+func getEnvInt(key string, defaultValue int64) int64 {
+	if value := os.Getenv(key); value != "" {
+		if parsed, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return parsed
+		}
+	}
+	return defaultValue
+}
+
+// getEnvInts parses a comma separated list of integers
+func getEnvInts(key string, defaultValue []int64) []int64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	var parsed []int64
+	for _, part := range strings.Split(value, ",") {
+		n, err := strconv.ParseInt(strings.TrimSpace(part), 10, 64)
+		if err != nil {
+			return defaultValue
+		}
+		parsed = append(parsed, n)
+	}
+	return parsed
+}
+
 func getEnvBool(key string, defaultValue bool) bool {
 	if value := os.Getenv(key); value != "" {
 		if parsed, err := strconv.ParseBool(value); err == nil {
@@ -0,0 +1,76 @@
+package corpactions
+
+import (
+	"github.com/MunishMummadi/mock-trade-algorithm/alpaca"
+	"github.com/MunishMummadi/mock-trade-algorithm/models"
+)
+
+// Adjustment selects which corporate actions are back-adjusted into bars
+type Adjustment string
+
+const (
+	AdjustmentNone  Adjustment = "none"
+	AdjustmentSplit Adjustment = "split"
+	AdjustmentAll   Adjustment = "all" // splits and dividends
+)
+
+// AdjustBars returns a copy of bars back-adjusted for the given actions so
+// prices before each ex-date are comparable with prices after it. Splits
+// divide prices and multiply volume by the split ratio; dividends scale
+// prices by (1 - amount / prior close).
+func AdjustBars(bars []alpaca.MockBar, actions []*models.CorporateAction, mode Adjustment) []alpaca.MockBar {
+	adjusted := make([]alpaca.MockBar, len(bars))
+	copy(adjusted, bars)
+
+	if mode == AdjustmentNone {
+		return adjusted
+	}
+
+	for _, action := range actions {
+		// Bars strictly before the ex-date are affected
+		cutoff := 0
+		for cutoff < len(adjusted) && adjusted[cutoff].Timestamp.Before(action.ExDate) {
+			cutoff++
+		}
+		if cutoff == 0 {
+			continue
+		}
+
+		priceFactor := 1.0
+		volumeFactor := 1.0
+
+		switch action.Type {
+		case models.CorporateActionSplit:
+			ratio := action.Ratio.InexactFloat64()
+			if ratio <= 0 {
+				continue
+			}
+			priceFactor = 1 / ratio
+			volumeFactor = ratio
+		case models.CorporateActionDividend:
+			if mode != AdjustmentAll {
+				continue
+			}
+			priorClose := adjusted[cutoff-1].Close
+			if priorClose <= 0 {
+				continue
+			}
+			priceFactor = 1 - action.Amount.InexactFloat64()/priorClose
+			if priceFactor <= 0 {
+				continue
+			}
+		default:
+			continue
+		}
+
+		for i := 0; i < cutoff; i++ {
+			adjusted[i].Open *= priceFactor
+			adjusted[i].High *= priceFactor
+			adjusted[i].Low *= priceFactor
+			adjusted[i].Close *= priceFactor
+			adjusted[i].Volume = int64(float64(adjusted[i].Volume) * volumeFactor)
+		}
+	}
+
+	return adjusted
+}
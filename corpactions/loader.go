@@ -0,0 +1,105 @@
+package corpactions
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/MunishMummadi/mock-trade-algorithm/database"
+	"github.com/MunishMummadi/mock-trade-algorithm/models"
+)
+
+// dateLayout is the layout of ex-dates and pay dates in action files
+const dateLayout = "2006-01-02"
+
+// actionSpec is one corporate action as written in an action file
+type actionSpec struct {
+	Symbol  string          `json:"symbol"`
+	Type    string          `json:"type"`
+	ExDate  string          `json:"ex_date"`
+	PayDate string          `json:"pay_date"` // dividends only
+	Ratio   decimal.Decimal `json:"ratio"`    // splits only
+	Amount  decimal.Decimal `json:"amount"`   // dividends only
+}
+
+// LoadFile reads a JSON array of corporate actions and stores those not
+// recorded yet, returning how many were new. Dates are local calendar days,
+// matching the market calendars:
+//
+//	[
+//	  {"symbol": "NVDA", "type": "split", "ex_date": "2026-06-10", "ratio": "10"},
+//	  {"symbol": "MSFT", "type": "dividend", "ex_date": "2026-08-14", "pay_date": "2026-09-11", "amount": "0.83"}
+//	]
+//
+// Every action is validated before any is stored.
+func LoadFile(db *database.Database, path string) (int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read corporate actions file: %w", err)
+	}
+
+	var specs []actionSpec
+	if err := json.Unmarshal(data, &specs); err != nil {
+		return 0, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	actions := make([]*models.CorporateAction, 0, len(specs))
+	for i, spec := range specs {
+		action, err := spec.action()
+		if err != nil {
+			return 0, fmt.Errorf("%s: action %d: %w", path, i+1, err)
+		}
+		actions = append(actions, action)
+	}
+
+	created := 0
+	for _, action := range actions {
+		isNew, err := db.CreateCorporateAction(action)
+		if err != nil {
+			return created, err
+		}
+		if isNew {
+			created++
+		}
+	}
+	return created, nil
+}
+
+// action validates the spec and builds the corporate action it describes
+func (s actionSpec) action() (*models.CorporateAction, error) {
+	symbol := strings.TrimSpace(s.Symbol)
+	if symbol == "" {
+		return nil, fmt.Errorf("symbol is required")
+	}
+
+	exDate, err := time.ParseInLocation(dateLayout, s.ExDate, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ex_date %q, expected YYYY-MM-DD", s.ExDate)
+	}
+
+	switch models.CorporateActionType(strings.ToLower(s.Type)) {
+	case models.CorporateActionSplit:
+		if !s.Ratio.IsPositive() {
+			return nil, fmt.Errorf("split of %s needs a positive ratio", symbol)
+		}
+		return models.NewSplit(symbol, exDate, s.Ratio), nil
+	case models.CorporateActionDividend:
+		if !s.Amount.IsPositive() {
+			return nil, fmt.Errorf("dividend of %s needs a positive amount", symbol)
+		}
+		payDate, err := time.ParseInLocation(dateLayout, s.PayDate, time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pay_date %q, expected YYYY-MM-DD", s.PayDate)
+		}
+		if payDate.Before(exDate) {
+			return nil, fmt.Errorf("dividend of %s is paid before its ex-date", symbol)
+		}
+		return models.NewDividend(symbol, exDate, payDate, s.Amount), nil
+	default:
+		return nil, fmt.Errorf("unknown type %q (use split or dividend)", s.Type)
+	}
+}
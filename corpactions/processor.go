@@ -0,0 +1,199 @@
+package corpactions
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/MunishMummadi/mock-trade-algorithm/database"
+	"github.com/MunishMummadi/mock-trade-algorithm/models"
+)
+
+// Processor applies corporate actions to a user's positions and cash
+type Processor struct {
+	db *database.Database
+}
+
+func NewProcessor(db *database.Database) *Processor {
+	return &Processor{db: db}
+}
+
+// Process applies every action outstanding for the user up to asOf. Each
+// action is tracked per user in an entitlement that snapshots the shares
+// held before the ex-date, rebuilt from the user's fills, so processing an
+// action late gives the same result as on the day. On a split's ex-date the
+// entitled shares are rescaled; on a dividend's ex-date the entitlement is
+// recorded, and on its pay date the cash is credited. It returns the splits
+// applied so callers can rescale market prices.
+func (p *Processor) Process(userID int64, asOf time.Time) ([]*models.CorporateAction, error) {
+	entitlements, err := p.db.GetOutstandingEntitlements(userID, asOf)
+	if err != nil {
+		return nil, err
+	}
+
+	var splits []*models.CorporateAction
+	for _, entitlement := range entitlements {
+		action := entitlement.Action
+		switch action.Type {
+		case models.CorporateActionSplit:
+			if err := p.applySplit(entitlement); err != nil {
+				return splits, err
+			}
+			splits = append(splits, action)
+		case models.CorporateActionDividend:
+			if !entitlement.Applied {
+				if err := p.recordEntitlement(entitlement); err != nil {
+					return splits, err
+				}
+			}
+			if !entitlement.Paid && !action.PayDate.After(asOf) {
+				if err := p.payDividend(entitlement); err != nil {
+					return splits, err
+				}
+			}
+		}
+	}
+
+	return splits, nil
+}
+
+func (p *Processor) applySplit(entitlement *models.Entitlement) error {
+	action := entitlement.Action
+	held, err := p.holdingBefore(entitlement.UserID, action.Symbol, action.ExDate)
+	if err != nil {
+		return err
+	}
+	entitlement.Quantity = held
+
+	if !held.IsZero() {
+		position, err := p.position(entitlement.UserID, action.Symbol)
+		if err != nil {
+			return err
+		}
+		before := position.Quantity
+		position.ApplySplit(held, action.Ratio)
+		if err := p.db.UpsertPortfolio(position); err != nil {
+			return err
+		}
+
+		entry := &models.LedgerEntry{
+			UserID:      entitlement.UserID,
+			Type:        models.LedgerEntrySplit,
+			Symbol:      action.Symbol,
+			Quantity:    position.Quantity.Sub(before),
+			Reference:   action.ID,
+			Description: fmt.Sprintf("%s-for-1 split of %s", action.Ratio.String(), action.Symbol),
+			CreatedAt:   time.Now(),
+		}
+		if err := p.db.CreateLedgerEntry(entry); err != nil {
+			return err
+		}
+
+		log.Printf("Applied %s-for-1 split to %s: %s -> %s units",
+			action.Ratio.String(), action.Symbol, before.String(), position.Quantity.String())
+	}
+
+	entitlement.Applied = true
+	entitlement.UpdatedAt = time.Now()
+	return p.db.UpsertEntitlement(entitlement)
+}
+
+func (p *Processor) recordEntitlement(entitlement *models.Entitlement) error {
+	action := entitlement.Action
+	held, err := p.holdingBefore(entitlement.UserID, action.Symbol, action.ExDate)
+	if err != nil {
+		return err
+	}
+
+	// Only long holdings before the ex-date are entitled to the dividend
+	if held.IsPositive() {
+		entitlement.Quantity = held
+	}
+
+	entitlement.Applied = true
+	entitlement.UpdatedAt = time.Now()
+	return p.db.UpsertEntitlement(entitlement)
+}
+
+func (p *Processor) payDividend(entitlement *models.Entitlement) error {
+	action := entitlement.Action
+	cash := entitlement.Quantity.Mul(action.Amount)
+
+	if cash.IsPositive() {
+		user, err := p.db.GetUser(entitlement.UserID)
+		if err != nil {
+			return err
+		}
+		user.UpdateBalance(cash)
+		if err := p.db.UpdateUser(user); err != nil {
+			return err
+		}
+
+		entry := &models.LedgerEntry{
+			UserID:    entitlement.UserID,
+			Type:      models.LedgerEntryDividend,
+			Symbol:    action.Symbol,
+			Amount:    cash,
+			Reference: action.ID,
+			Description: fmt.Sprintf("Dividend of $%s/share on %s units of %s",
+				action.Amount.String(), entitlement.Quantity.String(), action.Symbol),
+			CreatedAt: time.Now(),
+		}
+		if err := p.db.CreateLedgerEntry(entry); err != nil {
+			return err
+		}
+
+		log.Printf("Credited dividend for %s: $%.2f", action.Symbol, cash.InexactFloat64())
+	}
+
+	entitlement.Paid = true
+	entitlement.UpdatedAt = time.Now()
+	return p.db.UpsertEntitlement(entitlement)
+}
+
+// holdingBefore rebuilds the signed quantity a user held in a symbol just
+// before exDate from the fills before it, rescaling each fill by the splits
+// that went ex between the fill and exDate
+func (p *Processor) holdingBefore(userID int64, symbol string, exDate time.Time) (decimal.Decimal, error) {
+	trades, err := p.db.GetFilledTradesBefore(userID, symbol, exDate)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	actions, err := p.db.GetCorporateActions(symbol)
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	held := decimal.Zero
+	for _, trade := range trades {
+		quantity := trade.Quantity
+		for _, action := range actions {
+			if action.Type == models.CorporateActionSplit && action.Ratio.IsPositive() &&
+				action.ExDate.After(*trade.FilledAt) && action.ExDate.Before(exDate) {
+				quantity = quantity.Mul(action.Ratio)
+			}
+		}
+
+		if trade.Side == models.OrderSideSell {
+			quantity = quantity.Neg()
+		}
+		held = held.Add(quantity)
+	}
+	return held, nil
+}
+
+// position returns the user's position in a symbol, or an empty one
+func (p *Processor) position(userID int64, symbol string) (*models.Portfolio, error) {
+	portfolio, err := p.db.GetPortfolioByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, position := range portfolio {
+		if position.Symbol == symbol {
+			return position, nil
+		}
+	}
+	return &models.Portfolio{UserID: userID, Symbol: symbol}, nil
+}
@@ -0,0 +1,256 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/MunishMummadi/mock-trade-algorithm/models"
+)
+
+// Corporate action operations
+
+// CreateCorporateAction stores an action unless one of the same type is
+// already recorded for the symbol on that ex-date, reporting whether it was
+// new
+func (d *Database) CreateCorporateAction(action *models.CorporateAction) (bool, error) {
+	query := `INSERT OR IGNORE INTO corporate_actions (symbol, type, ex_date, pay_date, ratio,
+			  amount, created_at) VALUES (?, ?, ?, ?, ?, ?, ?)`
+
+	result, err := d.db.Exec(query, action.Symbol, action.Type, action.ExDate, action.PayDate,
+		action.Ratio.String(), action.Amount.String(), action.CreatedAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to create corporate action: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get corporate action rows affected: %w", err)
+	}
+	if affected == 0 {
+		return false, nil
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return false, fmt.Errorf("failed to get corporate action ID: %w", err)
+	}
+	action.ID = id
+
+	return true, nil
+}
+
+// GetCorporateActions returns every action for a symbol ordered by ex-date
+func (d *Database) GetCorporateActions(symbol string) ([]*models.CorporateAction, error) {
+	query := `SELECT id, symbol, type, ex_date, pay_date, ratio, amount, created_at
+			  FROM corporate_actions WHERE symbol = ? ORDER BY ex_date`
+
+	rows, err := d.db.Query(query, symbol)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query corporate actions: %w", err)
+	}
+	defer rows.Close()
+
+	var actions []*models.CorporateAction
+	for rows.Next() {
+		action, err := scanCorporateAction(rows)
+		if err != nil {
+			return nil, err
+		}
+		actions = append(actions, action)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query corporate actions: %w", err)
+	}
+
+	return actions, nil
+}
+
+// GetOutstandingEntitlements returns a user's entitlements to actions whose
+// ex-date or pay-date has been reached but which have not been fully
+// processed for the user. Actions the user has no entitlement row for yet
+// come back as new, unapplied entitlements.
+func (d *Database) GetOutstandingEntitlements(userID int64, asOf time.Time) ([]*models.Entitlement, error) {
+	query := `SELECT a.id, a.symbol, a.type, a.ex_date, a.pay_date, a.ratio, a.amount, a.created_at,
+			  COALESCE(e.quantity, '0'), COALESCE(e.applied, 0), COALESCE(e.paid, 0)
+			  FROM corporate_actions a
+			  LEFT JOIN corporate_action_entitlements e ON e.action_id = a.id AND e.user_id = ?
+			  WHERE a.ex_date <= ? AND (e.action_id IS NULL OR e.applied = 0
+			  OR (a.type = ? AND e.paid = 0 AND a.pay_date <= ?))
+			  ORDER BY a.ex_date`
+
+	rows, err := d.db.Query(query, userID, asOf, models.CorporateActionDividend, asOf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query corporate action entitlements: %w", err)
+	}
+	defer rows.Close()
+
+	var entitlements []*models.Entitlement
+	for rows.Next() {
+		action := &models.CorporateAction{}
+		entitlement := &models.Entitlement{UserID: userID, Action: action}
+		var ratioStr, amountStr, quantityStr string
+
+		err := rows.Scan(&action.ID, &action.Symbol, &action.Type, &action.ExDate,
+			&action.PayDate, &ratioStr, &amountStr, &action.CreatedAt,
+			&quantityStr, &entitlement.Applied, &entitlement.Paid)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan corporate action entitlement: %w", err)
+		}
+
+		// Parse decimal fields
+		if action.Ratio, err = decimal.NewFromString(ratioStr); err != nil {
+			return nil, fmt.Errorf("failed to parse ratio: %w", err)
+		}
+		if action.Amount, err = decimal.NewFromString(amountStr); err != nil {
+			return nil, fmt.Errorf("failed to parse amount: %w", err)
+		}
+		if entitlement.Quantity, err = decimal.NewFromString(quantityStr); err != nil {
+			return nil, fmt.Errorf("failed to parse entitled quantity: %w", err)
+		}
+		entitlement.ActionID = action.ID
+
+		entitlements = append(entitlements, entitlement)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query corporate action entitlements: %w", err)
+	}
+
+	return entitlements, nil
+}
+
+func (d *Database) UpsertEntitlement(entitlement *models.Entitlement) error {
+	query := `INSERT OR REPLACE INTO corporate_action_entitlements (action_id, user_id, quantity,
+			  applied, paid, updated_at) VALUES (?, ?, ?, ?, ?, ?)`
+
+	_, err := d.db.Exec(query, entitlement.ActionID, entitlement.UserID,
+		entitlement.Quantity.String(), entitlement.Applied, entitlement.Paid, entitlement.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to upsert corporate action entitlement: %w", err)
+	}
+
+	return nil
+}
+
+func scanCorporateAction(rows *sql.Rows) (*models.CorporateAction, error) {
+	action := &models.CorporateAction{}
+	var ratioStr, amountStr string
+
+	err := rows.Scan(&action.ID, &action.Symbol, &action.Type, &action.ExDate,
+		&action.PayDate, &ratioStr, &amountStr, &action.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan corporate action: %w", err)
+	}
+
+	// Parse decimal fields
+	if action.Ratio, err = decimal.NewFromString(ratioStr); err != nil {
+		return nil, fmt.Errorf("failed to parse ratio: %w", err)
+	}
+	if action.Amount, err = decimal.NewFromString(amountStr); err != nil {
+		return nil, fmt.Errorf("failed to parse amount: %w", err)
+	}
+
+	return action, nil
+}
+
+// GetFilledTradesBefore returns a user's trades in a symbol that filled
+// before the given time, oldest first
+func (d *Database) GetFilledTradesBefore(userID int64, symbol string, before time.Time) ([]*models.Trade, error) {
+	query := `SELECT side, quantity, filled_at FROM trades
+			  WHERE user_id = ? AND symbol = ? AND status = ? AND filled_at < ?
+			  ORDER BY filled_at`
+
+	rows, err := d.db.Query(query, userID, symbol, models.TradeStatusFilled, before)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query filled trades: %w", err)
+	}
+	defer rows.Close()
+
+	var trades []*models.Trade
+	for rows.Next() {
+		trade := &models.Trade{UserID: userID, Symbol: symbol, Status: models.TradeStatusFilled}
+		var quantityStr string
+		var filledAt time.Time
+
+		if err := rows.Scan(&trade.Side, &quantityStr, &filledAt); err != nil {
+			return nil, fmt.Errorf("failed to scan filled trade: %w", err)
+		}
+		if trade.Quantity, err = decimal.NewFromString(quantityStr); err != nil {
+			return nil, fmt.Errorf("failed to parse quantity: %w", err)
+		}
+		trade.FilledAt = &filledAt
+
+		trades = append(trades, trade)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query filled trades: %w", err)
+	}
+
+	return trades, nil
+}
+
+// Ledger operations
+func (d *Database) CreateLedgerEntry(entry *models.LedgerEntry) error {
+	query := `INSERT INTO ledger_entries (user_id, type, symbol, amount, quantity, reference,
+			  description, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+
+	result, err := d.db.Exec(query, entry.UserID, entry.Type, entry.Symbol,
+		entry.Amount.String(), entry.Quantity.String(), entry.Reference,
+		entry.Description, entry.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create ledger entry: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get ledger entry ID: %w", err)
+	}
+	entry.ID = id
+
+	return nil
+}
+
+func (d *Database) GetLedgerEntries(userID int64, limit int) ([]*models.LedgerEntry, error) {
+	query := `SELECT id, user_id, type, symbol, amount, quantity, reference, description,
+			  created_at FROM ledger_entries WHERE user_id = ?
+			  ORDER BY created_at DESC LIMIT ?`
+
+	rows, err := d.db.Query(query, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query ledger entries: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*models.LedgerEntry
+	for rows.Next() {
+		entry := &models.LedgerEntry{}
+		var amountStr, quantityStr string
+		var reference sql.NullInt64
+		var description sql.NullString
+
+		err := rows.Scan(&entry.ID, &entry.UserID, &entry.Type, &entry.Symbol, &amountStr,
+			&quantityStr, &reference, &description, &entry.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan ledger entry: %w", err)
+		}
+
+		// Parse decimal fields
+		if entry.Amount, err = decimal.NewFromString(amountStr); err != nil {
+			return nil, fmt.Errorf("failed to parse amount: %w", err)
+		}
+		if entry.Quantity, err = decimal.NewFromString(quantityStr); err != nil {
+			return nil, fmt.Errorf("failed to parse quantity: %w", err)
+		}
+		entry.Reference = reference.Int64
+		entry.Description = description.String
+
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read ledger entries: %w", err)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,69 @@
+package database
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/MunishMummadi/mock-trade-algorithm/models"
+)
+
+// Market data operations
+func (d *Database) UpsertMarketData(data *models.MarketData) error {
+	query := `INSERT OR REPLACE INTO market_data (symbol, price, volume, high, low, open, 
+			  close, timestamp) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+
+	_, err := d.db.Exec(query, data.Symbol, data.Price.String(), data.Volume, data.High.String(),
+		data.Low.String(), data.Open.String(), data.Close.String(), data.Timestamp)
+	if err != nil {
+		return fmt.Errorf("failed to upsert market data: %w", err)
+	}
+
+	return nil
+}
+
+// GetMarketData returns a symbol's market data in [from, to), oldest first
+func (d *Database) GetMarketData(symbol string, from, to time.Time) ([]*models.MarketData, error) {
+	query := `SELECT symbol, price, volume, high, low, open, close, timestamp 
+			  FROM market_data WHERE symbol = ? AND timestamp >= ? AND timestamp < ? 
+			  ORDER BY timestamp`
+
+	rows, err := d.db.Query(query, symbol, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query market data: %w", err)
+	}
+	defer rows.Close()
+
+	var data []*models.MarketData
+	for rows.Next() {
+		md := &models.MarketData{}
+		var priceStr, highStr, lowStr, openStr, closeStr string
+
+		err := rows.Scan(&md.Symbol, &priceStr, &md.Volume, &highStr, &lowStr,
+			&openStr, &closeStr, &md.Timestamp)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan market data: %w", err)
+		}
+
+		// Parse decimal fields
+		for _, field := range []struct {
+			value string
+			dest  *decimal.Decimal
+		}{
+			{priceStr, &md.Price}, {highStr, &md.High}, {lowStr, &md.Low},
+			{openStr, &md.Open}, {closeStr, &md.Close},
+		} {
+			if *field.dest, err = decimal.NewFromString(field.value); err != nil {
+				return nil, fmt.Errorf("failed to parse market data price: %w", err)
+			}
+		}
+
+		data = append(data, md)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read market data: %w", err)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,78 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/MunishMummadi/mock-trade-algorithm/models"
+)
+
+// Regime history operations
+func (d *Database) CreateRegimeRecord(record *models.RegimeRecord) error {
+	query := `INSERT INTO regime_history (symbol, regime, adx, volatility, volatility_ratio, hurst, created_at)
+			  VALUES (?, ?, ?, ?, ?, ?, ?)`
+
+	result, err := d.db.Exec(query, record.Symbol, record.Regime, record.ADX, record.Volatility,
+		record.VolatilityRatio, record.Hurst, record.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create regime record: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get regime record ID: %w", err)
+	}
+
+	record.ID = id
+	return nil
+}
+
+// GetLatestRegimes returns the most recent regime of every symbol
+func (d *Database) GetLatestRegimes() ([]*models.RegimeRecord, error) {
+	query := `SELECT id, symbol, regime, adx, volatility, volatility_ratio, hurst, created_at
+			  FROM regime_history
+			  WHERE id IN (SELECT MAX(id) FROM regime_history GROUP BY symbol)
+			  ORDER BY symbol`
+
+	rows, err := d.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query regimes: %w", err)
+	}
+	defer rows.Close()
+
+	return scanRegimeRecords(rows)
+}
+
+// GetRegimeHistory returns a symbol's regime changes, newest first. An empty
+// symbol returns the history of every symbol.
+func (d *Database) GetRegimeHistory(symbol string, limit int) ([]*models.RegimeRecord, error) {
+	query := `SELECT id, symbol, regime, adx, volatility, volatility_ratio, hurst, created_at
+			  FROM regime_history WHERE (? = '' OR symbol = ?)
+			  ORDER BY created_at DESC, id DESC LIMIT ?`
+
+	rows, err := d.db.Query(query, symbol, symbol, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query regime history: %w", err)
+	}
+	defer rows.Close()
+
+	return scanRegimeRecords(rows)
+}
+
+func scanRegimeRecords(rows *sql.Rows) ([]*models.RegimeRecord, error) {
+	var records []*models.RegimeRecord
+	for rows.Next() {
+		record := &models.RegimeRecord{}
+		err := rows.Scan(&record.ID, &record.Symbol, &record.Regime, &record.ADX,
+			&record.Volatility, &record.VolatilityRatio, &record.Hurst, &record.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan regime record: %w", err)
+		}
+		records = append(records, record)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read regime records: %w", err)
+	}
+
+	return records, nil
+}
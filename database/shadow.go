@@ -0,0 +1,141 @@
+package database
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/MunishMummadi/mock-trade-algorithm/models"
+)
+
+// Shadow trade operations
+
+// CreateShadowTrades stores the fills of one shadow signal in a single
+// transaction, so either every leg is recorded or none is
+func (d *Database) CreateShadowTrades(trades []*models.ShadowTrade) error {
+	query := `INSERT INTO shadow_trades (strategy, symbol, side, quantity, price, notes, created_at)
+			  VALUES (?, ?, ?, ?, ?, ?, ?)`
+
+	tx, err := d.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin shadow trade transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	ids := make([]int64, len(trades))
+	for i, trade := range trades {
+		result, err := tx.Exec(query, trade.Strategy, trade.Symbol, trade.Side,
+			trade.Quantity.String(), trade.Price.String(), trade.Notes, trade.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to create shadow trade: %w", err)
+		}
+
+		if ids[i], err = result.LastInsertId(); err != nil {
+			return fmt.Errorf("failed to get shadow trade ID: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit shadow trades: %w", err)
+	}
+	for i, trade := range trades {
+		trade.ID = ids[i]
+	}
+	return nil
+}
+
+// GetShadowTrades returns every shadow trade of a strategy, oldest first
+func (d *Database) GetShadowTrades(strategy string) ([]*models.ShadowTrade, error) {
+	query := `SELECT id, strategy, symbol, side, quantity, price, notes, created_at
+			  FROM shadow_trades WHERE strategy = ? ORDER BY created_at, id`
+
+	rows, err := d.db.Query(query, strategy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query shadow trades: %w", err)
+	}
+	defer rows.Close()
+
+	var trades []*models.ShadowTrade
+	for rows.Next() {
+		trade := &models.ShadowTrade{}
+		var quantityStr, priceStr string
+
+		err := rows.Scan(&trade.ID, &trade.Strategy, &trade.Symbol, &trade.Side,
+			&quantityStr, &priceStr, &trade.Notes, &trade.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan shadow trade: %w", err)
+		}
+
+		if trade.Quantity, err = decimal.NewFromString(quantityStr); err != nil {
+			return nil, fmt.Errorf("failed to parse shadow trade quantity: %w", err)
+		}
+		if trade.Price, err = decimal.NewFromString(priceStr); err != nil {
+			return nil, fmt.Errorf("failed to parse shadow trade price: %w", err)
+		}
+
+		trades = append(trades, trade)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read shadow trades: %w", err)
+	}
+
+	return trades, nil
+}
+
+// Equity snapshot operations
+func (d *Database) CreateEquitySnapshot(snapshot *models.EquitySnapshot) error {
+	query := `INSERT INTO equity_snapshots (account, cash, equity, created_at) VALUES (?, ?, ?, ?)`
+
+	result, err := d.db.Exec(query, snapshot.Account, snapshot.Cash.String(),
+		snapshot.Equity.String(), snapshot.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create equity snapshot: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get equity snapshot ID: %w", err)
+	}
+
+	snapshot.ID = id
+	return nil
+}
+
+// GetEquitySnapshots returns the snapshots of every account taken at or
+// after from, oldest first
+func (d *Database) GetEquitySnapshots(from time.Time) ([]*models.EquitySnapshot, error) {
+	query := `SELECT id, account, cash, equity, created_at
+			  FROM equity_snapshots WHERE created_at >= ? ORDER BY created_at, id`
+
+	rows, err := d.db.Query(query, from)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query equity snapshots: %w", err)
+	}
+	defer rows.Close()
+
+	var snapshots []*models.EquitySnapshot
+	for rows.Next() {
+		snapshot := &models.EquitySnapshot{}
+		var cashStr, equityStr string
+
+		err := rows.Scan(&snapshot.ID, &snapshot.Account, &cashStr, &equityStr, &snapshot.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan equity snapshot: %w", err)
+		}
+
+		if snapshot.Cash, err = decimal.NewFromString(cashStr); err != nil {
+			return nil, fmt.Errorf("failed to parse equity snapshot cash: %w", err)
+		}
+		if snapshot.Equity, err = decimal.NewFromString(equityStr); err != nil {
+			return nil, fmt.Errorf("failed to parse equity snapshot equity: %w", err)
+		}
+
+		snapshots = append(snapshots, snapshot)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read equity snapshots: %w", err)
+	}
+
+	return snapshots, nil
+}
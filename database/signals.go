@@ -0,0 +1,174 @@
+package database
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/MunishMummadi/mock-trade-algorithm/models"
+)
+
+// SignalFilter narrows ListTradingSignals. Zero values match everything.
+type SignalFilter struct {
+	Symbol   string
+	Strategy string
+	From     time.Time // inclusive
+	To       time.Time // exclusive
+	Limit    int
+}
+
+// Trading signal operations
+func (d *Database) CreateTradingSignal(signal *models.TradingSignal) error {
+	query := `INSERT INTO trading_signals (symbol, signal, strength, raw_strength, price, 
+			  strategy, indicators, legs, reason, decision, trade_id, created_at) 
+			  VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	// Uncalibrated signals report the same raw and calibrated strength
+	rawStrength := signal.RawStrength
+	if rawStrength == 0 {
+		rawStrength = signal.Strength
+	}
+
+	indicators := ""
+	if len(signal.Indicators) > 0 {
+		encoded, err := json.Marshal(signal.Indicators)
+		if err != nil {
+			return fmt.Errorf("failed to encode signal indicators: %w", err)
+		}
+		indicators = string(encoded)
+	}
+
+	legs := ""
+	if len(signal.Legs) > 0 {
+		encoded, err := json.Marshal(signal.Legs)
+		if err != nil {
+			return fmt.Errorf("failed to encode signal legs: %w", err)
+		}
+		legs = string(encoded)
+	}
+
+	var tradeID sql.NullInt64
+	if signal.TradeID != 0 {
+		tradeID = sql.NullInt64{Int64: signal.TradeID, Valid: true}
+	}
+
+	result, err := d.db.Exec(query, signal.Symbol, signal.Signal, signal.Strength, rawStrength,
+		signal.Price.String(), signal.Strategy, indicators, legs, signal.Reason, signal.Decision,
+		tradeID, signal.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create trading signal: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get trading signal ID: %w", err)
+	}
+	signal.ID = id
+
+	return nil
+}
+
+// ListTradingSignals returns signals matching the filter, newest first
+func (d *Database) ListTradingSignals(filter SignalFilter) ([]*models.TradingSignal, error) {
+	var conditions []string
+	var args []interface{}
+
+	if filter.Symbol != "" {
+		conditions = append(conditions, "symbol = ?")
+		args = append(args, filter.Symbol)
+	}
+	if filter.Strategy != "" {
+		conditions = append(conditions, "strategy = ?")
+		args = append(args, filter.Strategy)
+	}
+	if !filter.From.IsZero() {
+		conditions = append(conditions, "created_at >= ?")
+		args = append(args, filter.From)
+	}
+	if !filter.To.IsZero() {
+		conditions = append(conditions, "created_at < ?")
+		args = append(args, filter.To)
+	}
+
+	query := `SELECT id, symbol, signal, strength, raw_strength, price, strategy, indicators, 
+			  legs, reason, decision, trade_id, created_at FROM trading_signals`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY created_at DESC, id DESC"
+	if filter.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, filter.Limit)
+	}
+
+	rows, err := d.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query trading signals: %w", err)
+	}
+	defer rows.Close()
+
+	return scanTradingSignals(rows)
+}
+
+// GetSignalsForTrade returns the signals that led to a trade
+func (d *Database) GetSignalsForTrade(tradeID int64) ([]*models.TradingSignal, error) {
+	query := `SELECT id, symbol, signal, strength, raw_strength, price, strategy, indicators, 
+			  legs, reason, decision, trade_id, created_at FROM trading_signals WHERE trade_id = ? ORDER BY id`
+
+	rows, err := d.db.Query(query, tradeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query trading signals: %w", err)
+	}
+	defer rows.Close()
+
+	return scanTradingSignals(rows)
+}
+
+func scanTradingSignals(rows *sql.Rows) ([]*models.TradingSignal, error) {
+	var signals []*models.TradingSignal
+	for rows.Next() {
+		signal := &models.TradingSignal{}
+		var priceStr, indicators, legs string
+		var tradeID sql.NullInt64
+		var rawStrength sql.NullFloat64
+
+		err := rows.Scan(&signal.ID, &signal.Symbol, &signal.Signal, &signal.Strength,
+			&rawStrength, &priceStr, &signal.Strategy, &indicators, &legs, &signal.Reason, &signal.Decision,
+			&tradeID, &signal.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan trading signal: %w", err)
+		}
+
+		if signal.Price, err = decimal.NewFromString(priceStr); err != nil {
+			return nil, fmt.Errorf("failed to parse price: %w", err)
+		}
+		if indicators != "" {
+			if err := json.Unmarshal([]byte(indicators), &signal.Indicators); err != nil {
+				return nil, fmt.Errorf("failed to parse signal indicators: %w", err)
+			}
+		}
+		if legs != "" {
+			if err := json.Unmarshal([]byte(legs), &signal.Legs); err != nil {
+				return nil, fmt.Errorf("failed to parse signal legs: %w", err)
+			}
+		}
+		signal.TradeID = tradeID.Int64
+
+		// Rows from before calibration only have the strategy's strength
+		signal.RawStrength = signal.Strength
+		if rawStrength.Valid {
+			signal.RawStrength = rawStrength.Float64
+		}
+
+		signals = append(signals, signal)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read trading signals: %w", err)
+	}
+
+	return signals, nil
+}
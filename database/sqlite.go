@@ -108,6 +108,95 @@ func (d *Database) migrate() error {
 			timestamp DATETIME NOT NULL,
 			PRIMARY KEY (symbol, timestamp)
 		)`,
+		`CREATE TABLE IF NOT EXISTS symbols (
+			symbol TEXT PRIMARY KEY,
+			asset_class TEXT NOT NULL,
+			exchange TEXT NOT NULL,
+			sector TEXT NOT NULL,
+			quote_currency TEXT NOT NULL,
+			tick_size TEXT NOT NULL,
+			min_lot TEXT NOT NULL,
+			shortable BOOLEAN NOT NULL DEFAULT 0,
+			marginable BOOLEAN NOT NULL DEFAULT 0,
+			reference_price TEXT NOT NULL DEFAULT '0',
+			active BOOLEAN NOT NULL DEFAULT 1,
+			updated_at DATETIME NOT NULL
+		)`,
+		`CREATE TABLE IF NOT EXISTS corporate_actions (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			symbol TEXT NOT NULL,
+			type TEXT NOT NULL,
+			ex_date DATETIME NOT NULL,
+			pay_date DATETIME NOT NULL,
+			ratio TEXT NOT NULL DEFAULT '1',
+			amount TEXT NOT NULL DEFAULT '0',
+			created_at DATETIME NOT NULL
+		)`,
+		`CREATE TABLE IF NOT EXISTS corporate_action_entitlements (
+			action_id INTEGER NOT NULL,
+			user_id INTEGER NOT NULL,
+			quantity TEXT NOT NULL DEFAULT '0',
+			applied BOOLEAN NOT NULL DEFAULT 0,
+			paid BOOLEAN NOT NULL DEFAULT 0,
+			updated_at DATETIME NOT NULL,
+			PRIMARY KEY (action_id, user_id),
+			FOREIGN KEY (action_id) REFERENCES corporate_actions (id),
+			FOREIGN KEY (user_id) REFERENCES users (id)
+		)`,
+		`CREATE TABLE IF NOT EXISTS ledger_entries (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			user_id INTEGER NOT NULL,
+			type TEXT NOT NULL,
+			symbol TEXT NOT NULL,
+			amount TEXT NOT NULL DEFAULT '0',
+			quantity TEXT NOT NULL DEFAULT '0',
+			reference INTEGER,
+			description TEXT,
+			created_at DATETIME NOT NULL,
+			FOREIGN KEY (user_id) REFERENCES users (id)
+		)`,
+		`CREATE TABLE IF NOT EXISTS strategy_state (
+			strategy TEXT PRIMARY KEY,
+			state TEXT NOT NULL,
+			updated_at DATETIME NOT NULL
+		)`,
+		`CREATE TABLE IF NOT EXISTS strategy_weights (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			strategy TEXT NOT NULL,
+			weight REAL NOT NULL,
+			hit_rate REAL NOT NULL,
+			mean_return REAL NOT NULL,
+			volatility REAL NOT NULL,
+			samples INTEGER NOT NULL,
+			created_at DATETIME NOT NULL
+		)`,
+		`CREATE TABLE IF NOT EXISTS shadow_trades (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			strategy TEXT NOT NULL,
+			symbol TEXT NOT NULL,
+			side TEXT NOT NULL,
+			quantity TEXT NOT NULL,
+			price TEXT NOT NULL,
+			notes TEXT NOT NULL DEFAULT '',
+			created_at DATETIME NOT NULL
+		)`,
+		`CREATE TABLE IF NOT EXISTS equity_snapshots (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			account TEXT NOT NULL,
+			cash TEXT NOT NULL,
+			equity TEXT NOT NULL,
+			created_at DATETIME NOT NULL
+		)`,
+		`CREATE TABLE IF NOT EXISTS regime_history (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			symbol TEXT NOT NULL,
+			regime TEXT NOT NULL,
+			adx REAL NOT NULL,
+			volatility REAL NOT NULL,
+			volatility_ratio REAL NOT NULL,
+			hurst REAL NOT NULL,
+			created_at DATETIME NOT NULL
+		)`,
 		`CREATE INDEX IF NOT EXISTS idx_trades_user_id ON trades (user_id)`,
 		`CREATE INDEX IF NOT EXISTS idx_trades_symbol ON trades (symbol)`,
 		`CREATE INDEX IF NOT EXISTS idx_trades_status ON trades (status)`,
@@ -117,6 +206,13 @@ func (d *Database) migrate() error {
 		`CREATE INDEX IF NOT EXISTS idx_signals_created_at ON trading_signals (created_at)`,
 		`CREATE INDEX IF NOT EXISTS idx_market_data_symbol ON market_data (symbol)`,
 		`CREATE INDEX IF NOT EXISTS idx_market_data_timestamp ON market_data (timestamp)`,
+		`CREATE INDEX IF NOT EXISTS idx_corporate_actions_symbol ON corporate_actions (symbol)`,
+		`CREATE UNIQUE INDEX IF NOT EXISTS idx_corporate_actions_unique ON corporate_actions (symbol, type, ex_date)`,
+		`CREATE INDEX IF NOT EXISTS idx_ledger_entries_user_id ON ledger_entries (user_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_strategy_weights_strategy ON strategy_weights (strategy, created_at)`,
+		`CREATE INDEX IF NOT EXISTS idx_shadow_trades_strategy ON shadow_trades (strategy, created_at)`,
+		`CREATE INDEX IF NOT EXISTS idx_equity_snapshots_account ON equity_snapshots (account, created_at)`,
+		`CREATE INDEX IF NOT EXISTS idx_regime_history_symbol ON regime_history (symbol, created_at)`,
 	}
 
 	for _, query := range queries {
@@ -125,10 +221,68 @@ func (d *Database) migrate() error {
 		}
 	}
 
+	// Columns added after a table was first created
+	columns := []struct{ table, column, definition string }{
+		{"trading_signals", "decision", "TEXT NOT NULL DEFAULT ''"},
+		{"trading_signals", "trade_id", "INTEGER REFERENCES trades (id)"},
+		{"trading_signals", "indicators", "TEXT NOT NULL DEFAULT ''"},
+		{"trading_signals", "reason", "TEXT NOT NULL DEFAULT ''"},
+		{"trading_signals", "raw_strength", "REAL"},
+		{"trading_signals", "legs", "TEXT NOT NULL DEFAULT ''"},
+	}
+	for _, c := range columns {
+		if err := d.addColumnIfMissing(c.table, c.column, c.definition); err != nil {
+			return err
+		}
+	}
+
+	for _, query := range []string{
+		`CREATE INDEX IF NOT EXISTS idx_signals_strategy ON trading_signals (strategy)`,
+		`CREATE INDEX IF NOT EXISTS idx_signals_trade_id ON trading_signals (trade_id)`,
+	} {
+		if _, err := d.db.Exec(query); err != nil {
+			return fmt.Errorf("failed to execute migration query: %w", err)
+		}
+	}
+
 	log.Println("Database migration completed successfully")
 	return nil
 }
 
+// addColumnIfMissing adds a column to an existing table. SQLite has no
+// ADD COLUMN IF NOT EXISTS, so the table's columns are checked first.
+func (d *Database) addColumnIfMissing(table, column, definition string) error {
+	rows, err := d.db.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return fmt.Errorf("failed to inspect table %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			cid          int
+			name, kind   string
+			notNull, pk  int
+			defaultValue sql.NullString
+		)
+		if err := rows.Scan(&cid, &name, &kind, &notNull, &defaultValue, &pk); err != nil {
+			return fmt.Errorf("failed to scan column of %s: %w", table, err)
+		}
+		if name == column {
+			return nil
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to inspect table %s: %w", table, err)
+	}
+
+	query := fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition)
+	if _, err := d.db.Exec(query); err != nil {
+		return fmt.Errorf("failed to add column %s.%s: %w", table, column, err)
+	}
+	return nil
+}
+
 // User operations
 func (d *Database) CreateUser(user *models.User) error {
 	query := `INSERT INTO users (username, email, balance, created_at, updated_at) 
@@ -208,10 +362,11 @@ func (d *Database) CreateTrade(trade *models.Trade) error {
 
 func (d *Database) UpdateTrade(trade *models.Trade) error {
 	query := `UPDATE trades SET fill_price = ?, status = ?, commission = ?, 
-			  updated_at = ?, filled_at = ? WHERE id = ?`
+			  alpaca_order_id = ?, notes = ?, updated_at = ?, filled_at = ? WHERE id = ?`
 
 	_, err := d.db.Exec(query, trade.FillPrice.String(), trade.Status,
-		trade.Commission.String(), trade.UpdatedAt, trade.FilledAt, trade.ID)
+		trade.Commission.String(), trade.AlpacaOrderID, trade.Notes, trade.UpdatedAt,
+		trade.FilledAt, trade.ID)
 	if err != nil {
 		return fmt.Errorf("failed to update trade: %w", err)
 	}
@@ -231,19 +386,42 @@ func (d *Database) GetTradesByUser(userID int64, limit int) ([]*models.Trade, er
 	}
 	defer rows.Close()
 
+	return scanTrades(rows)
+}
+
+// GetTradesByStatus returns a user's trades in the given status, oldest first
+func (d *Database) GetTradesByStatus(userID int64, status models.TradeStatus) ([]*models.Trade, error) {
+	query := `SELECT id, user_id, symbol, side, type, quantity, price, fill_price, 
+			  status, commission, alpaca_order_id, strategy, notes, created_at, 
+			  updated_at, filled_at FROM trades WHERE user_id = ? AND status = ?
+			  ORDER BY created_at`
+
+	rows, err := d.db.Query(query, userID, status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query trades: %w", err)
+	}
+	defer rows.Close()
+
+	return scanTrades(rows)
+}
+
+func scanTrades(rows *sql.Rows) ([]*models.Trade, error) {
 	var trades []*models.Trade
 	for rows.Next() {
 		trade := &models.Trade{}
 		var quantityStr, priceStr, fillPriceStr, commissionStr string
+		var alpacaOrderID, notes sql.NullString
 		var filledAt sql.NullTime
 
 		err := rows.Scan(&trade.ID, &trade.UserID, &trade.Symbol, &trade.Side, &trade.Type,
 			&quantityStr, &priceStr, &fillPriceStr, &trade.Status, &commissionStr,
-			&trade.AlpacaOrderID, &trade.Strategy, &trade.Notes, &trade.CreatedAt,
+			&alpacaOrderID, &trade.Strategy, &notes, &trade.CreatedAt,
 			&trade.UpdatedAt, &filledAt)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan trade: %w", err)
 		}
+		trade.AlpacaOrderID = alpacaOrderID.String
+		trade.Notes = notes.String
 
 		// Parse decimal fields
 		if trade.Quantity, err = decimal.NewFromString(quantityStr); err != nil {
@@ -265,6 +443,9 @@ func (d *Database) GetTradesByUser(userID int64, limit int) ([]*models.Trade, er
 
 		trades = append(trades, trade)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query trades: %w", err)
+	}
 
 	return trades, nil
 }
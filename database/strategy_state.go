@@ -0,0 +1,38 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Strategy state operations
+func (d *Database) SaveStrategyState(strategy string, state []byte) error {
+	query := `INSERT OR REPLACE INTO strategy_state (strategy, state, updated_at) 
+			  VALUES (?, ?, ?)`
+
+	_, err := d.db.Exec(query, strategy, string(state), time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to save strategy state: %w", err)
+	}
+
+	return nil
+}
+
+// LoadStrategyState returns the saved state for a strategy, or nil if none
+// has been saved
+func (d *Database) LoadStrategyState(strategy string) ([]byte, error) {
+	query := `SELECT state FROM strategy_state WHERE strategy = ?`
+
+	var state string
+	err := d.db.QueryRow(query, strategy).Scan(&state)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load strategy state: %w", err)
+	}
+
+	return []byte(state), nil
+}
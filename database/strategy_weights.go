@@ -0,0 +1,78 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/MunishMummadi/mock-trade-algorithm/models"
+)
+
+// Strategy weight history operations
+func (d *Database) CreateStrategyWeight(weight *models.StrategyWeight) error {
+	query := `INSERT INTO strategy_weights (strategy, weight, hit_rate, mean_return, 
+			  volatility, samples, created_at) VALUES (?, ?, ?, ?, ?, ?, ?)`
+
+	result, err := d.db.Exec(query, weight.Strategy, weight.Weight, weight.HitRate,
+		weight.MeanReturn, weight.Volatility, weight.Samples, weight.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create strategy weight: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get strategy weight ID: %w", err)
+	}
+
+	weight.ID = id
+	return nil
+}
+
+// GetLatestStrategyWeights returns the most recent snapshot for every strategy
+func (d *Database) GetLatestStrategyWeights() ([]*models.StrategyWeight, error) {
+	query := `SELECT id, strategy, weight, hit_rate, mean_return, volatility, samples, created_at 
+			  FROM strategy_weights 
+			  WHERE id IN (SELECT MAX(id) FROM strategy_weights GROUP BY strategy) 
+			  ORDER BY strategy`
+
+	rows, err := d.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query strategy weights: %w", err)
+	}
+	defer rows.Close()
+
+	return scanStrategyWeights(rows)
+}
+
+// GetStrategyWeightHistory returns a strategy's snapshots, newest first. An
+// empty strategy name returns the history of every strategy.
+func (d *Database) GetStrategyWeightHistory(strategy string, limit int) ([]*models.StrategyWeight, error) {
+	query := `SELECT id, strategy, weight, hit_rate, mean_return, volatility, samples, created_at 
+			  FROM strategy_weights WHERE (? = '' OR strategy = ?) 
+			  ORDER BY created_at DESC, id DESC LIMIT ?`
+
+	rows, err := d.db.Query(query, strategy, strategy, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query strategy weight history: %w", err)
+	}
+	defer rows.Close()
+
+	return scanStrategyWeights(rows)
+}
+
+func scanStrategyWeights(rows *sql.Rows) ([]*models.StrategyWeight, error) {
+	var weights []*models.StrategyWeight
+	for rows.Next() {
+		weight := &models.StrategyWeight{}
+		err := rows.Scan(&weight.ID, &weight.Strategy, &weight.Weight, &weight.HitRate,
+			&weight.MeanReturn, &weight.Volatility, &weight.Samples, &weight.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan strategy weight: %w", err)
+		}
+		weights = append(weights, weight)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read strategy weights: %w", err)
+	}
+
+	return weights, nil
+}
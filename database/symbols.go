@@ -0,0 +1,68 @@
+package database
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/MunishMummadi/mock-trade-algorithm/models"
+)
+
+// Symbol registry operations
+func (d *Database) UpsertSymbol(asset *models.Asset) error {
+	query := `INSERT OR REPLACE INTO symbols (symbol, asset_class, exchange, sector, 
+			  quote_currency, tick_size, min_lot, shortable, marginable, reference_price, 
+			  active, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	_, err := d.db.Exec(query, asset.Symbol, asset.Class, asset.Exchange, asset.Sector,
+		asset.QuoteCurrency, asset.TickSize.String(), asset.MinLot.String(), asset.Shortable,
+		asset.Marginable, asset.ReferencePrice.String(), asset.Active, asset.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to upsert symbol: %w", err)
+	}
+
+	return nil
+}
+
+func (d *Database) GetSymbols() ([]*models.Asset, error) {
+	query := `SELECT symbol, asset_class, exchange, sector, quote_currency, tick_size, 
+			  min_lot, shortable, marginable, reference_price, active, updated_at 
+			  FROM symbols ORDER BY symbol`
+
+	rows, err := d.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query symbols: %w", err)
+	}
+	defer rows.Close()
+
+	var assets []*models.Asset
+	for rows.Next() {
+		asset := &models.Asset{}
+		var tickSizeStr, minLotStr, referencePriceStr string
+
+		err := rows.Scan(&asset.Symbol, &asset.Class, &asset.Exchange, &asset.Sector,
+			&asset.QuoteCurrency, &tickSizeStr, &minLotStr, &asset.Shortable,
+			&asset.Marginable, &referencePriceStr, &asset.Active, &asset.UpdatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan symbol: %w", err)
+		}
+
+		// Parse decimal fields
+		if asset.TickSize, err = decimal.NewFromString(tickSizeStr); err != nil {
+			return nil, fmt.Errorf("failed to parse tick size: %w", err)
+		}
+		if asset.MinLot, err = decimal.NewFromString(minLotStr); err != nil {
+			return nil, fmt.Errorf("failed to parse min lot: %w", err)
+		}
+		if asset.ReferencePrice, err = decimal.NewFromString(referencePriceStr); err != nil {
+			return nil, fmt.Errorf("failed to parse reference price: %w", err)
+		}
+
+		assets = append(assets, asset)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read symbols: %w", err)
+	}
+
+	return assets, nil
+}
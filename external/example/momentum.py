@@ -0,0 +1,54 @@
+#!/usr/bin/env python3
+"""Example external strategy: buys strong 20-bar momentum, sells weak.
+
+Run it from the engine with
+    EXTERNAL_STRATEGIES="python3 external/example/momentum.py"
+See the external package documentation for the protocol.
+"""
+import json
+import sys
+
+LOOKBACK = 20
+THRESHOLD = 0.05
+
+
+def send(message):
+    sys.stdout.write(json.dumps(message) + "\n")
+    sys.stdout.flush()
+
+
+def analyze(request):
+    closes = [bar["c"] for bar in request["bars"]]
+    reply = {"type": "signal", "id": request["id"], "signal": "HOLD"}
+    if len(closes) <= LOOKBACK:
+        return reply
+
+    ret = request["price"] / closes[-LOOKBACK - 1] - 1
+    reply["indicators"] = {"return_%d" % LOOKBACK: ret}
+    if abs(ret) >= THRESHOLD:
+        reply["signal"] = "BUY" if ret > 0 else "SELL"
+        reply["strength"] = min(1.0, 0.5 + abs(ret))
+        reply["reason"] = "%d-bar return %+.1f%%" % (LOOKBACK, ret * 100)
+    return reply
+
+
+def main():
+    for line in sys.stdin:
+        message = json.loads(line)
+        kind = message.get("type")
+        if kind == "hello":
+            send({"type": "hello", "name": "Python Momentum",
+                  "description": "20-bar momentum written in Python"})
+        elif kind == "analyze":
+            try:
+                send(analyze(message))
+            except Exception as exc:  # report instead of crashing
+                send({"type": "error", "id": message["id"], "error": str(exc)})
+        elif kind == "fill":
+            print("filled %(side)s %(quantity)s %(symbol)s at %(price)s" % message, file=sys.stderr)
+        elif kind == "shutdown":
+            break
+
+
+if __name__ == "__main__":
+    main()
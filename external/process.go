@@ -0,0 +1,182 @@
+package external
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os/exec"
+	"sync"
+	"time"
+)
+
+// errNoReply is returned when the reply doesn't arrive in time
+var errNoReply = errors.New("no reply in time")
+
+// maxLineSize bounds a single message from the process
+const maxLineSize = 4 << 20
+
+// process is one running instance of the strategy's command
+type process struct {
+	cmd     *exec.Cmd
+	stdin   io.WriteCloser
+	encoder *json.Encoder
+	replies chan reply    // closed when stdout closes
+	exited  chan struct{} // closed once the process has been reaped
+}
+
+// launch starts the command and the goroutines reading its output. label
+// prefixes its stderr lines in the log.
+func launch(command []string, label string) (*process, error) {
+	cmd := exec.Command(command[0], command[1:]...)
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open stdin: %w", err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open stdout: %w", err)
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open stderr: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start %s: %w", command[0], err)
+	}
+
+	p := &process{
+		cmd:     cmd,
+		stdin:   stdin,
+		encoder: json.NewEncoder(stdin),
+		replies: make(chan reply, 16),
+		exited:  make(chan struct{}),
+	}
+
+	var readers sync.WaitGroup
+	readers.Add(2)
+
+	go func() {
+		defer readers.Done()
+		scanner := bufio.NewScanner(stderr)
+		for scanner.Scan() {
+			log.Printf("[%s] %s", label, scanner.Text())
+		}
+	}()
+
+	go func() {
+		defer readers.Done()
+		defer close(p.replies)
+		scanner := bufio.NewScanner(stdout)
+		scanner.Buffer(make([]byte, 64*1024), maxLineSize)
+		for scanner.Scan() {
+			var r reply
+			if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+				log.Printf("Warning: %s wrote a line that isn't JSON: %.200s", label, scanner.Text())
+				continue
+			}
+
+			// Nothing reads replies between requests, so unsolicited
+			// messages beyond the buffer are dropped rather than blocking
+			select {
+			case p.replies <- r:
+			default:
+				log.Printf("Warning: dropped unexpected %q message from %s", r.Type, label)
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			log.Printf("Warning: failed to read from %s: %v", label, err)
+		}
+	}()
+
+	// Wait may only run once both pipes have been read to the end
+	go func() {
+		readers.Wait()
+		cmd.Wait()
+		close(p.exited)
+	}()
+
+	return p, nil
+}
+
+// send writes one message as a line
+func (p *process) send(message interface{}) error {
+	if err := p.encoder.Encode(message); err != nil {
+		return fmt.Errorf("failed to write to process: %w", err)
+	}
+	return nil
+}
+
+// write sends a message, giving up after timeout since a process that has
+// stopped reading its input blocks the write
+func (p *process) write(message interface{}, timeout time.Duration) error {
+	written := make(chan error, 1)
+	go func() {
+		written <- p.send(message)
+	}()
+
+	select {
+	case err := <-written:
+		return err
+	case <-time.After(timeout):
+		return fmt.Errorf("process stopped reading its input")
+	}
+}
+
+// request sends a message and waits for its reply, all within timeout
+func (p *process) request(message interface{}, kind string, id int64, timeout time.Duration) (reply, error) {
+	deadline := time.Now().Add(timeout)
+	if err := p.write(message, timeout); err != nil {
+		return reply{}, err
+	}
+	r, err := p.await(kind, id, time.Until(deadline))
+	if errors.Is(err, errNoReply) {
+		return r, fmt.Errorf("no reply within %s", timeout)
+	}
+	return r, err
+}
+
+// await returns the next reply of the wanted type and id, skipping stale
+// replies to requests that already timed out
+func (p *process) await(kind string, id int64, timeout time.Duration) (reply, error) {
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+
+	for {
+		select {
+		case r, open := <-p.replies:
+			if !open {
+				return reply{}, fmt.Errorf("process exited")
+			}
+			if r.Type == "error" && r.ID == id {
+				return reply{}, fmt.Errorf("process reported an error: %s", r.Error)
+			}
+			if r.Type == kind && r.ID == id {
+				return r, nil
+			}
+		case <-deadline.C:
+			return reply{}, errNoReply
+		}
+	}
+}
+
+// stop asks the process to exit, killing it if it hasn't after grace
+func (p *process) stop(grace time.Duration) {
+	p.write(shutdownRequest{Type: "shutdown"}, grace)
+	p.stdin.Close()
+
+	select {
+	case <-p.exited:
+	case <-time.After(grace):
+		p.kill()
+	}
+}
+
+// kill terminates the process immediately
+func (p *process) kill() {
+	p.cmd.Process.Kill()
+	<-p.exited
+}
@@ -0,0 +1,98 @@
+// Package external runs strategies written in other languages as child
+// processes that speak line-delimited JSON over stdin and stdout.
+//
+// Every message is one JSON object on one line with a "type" field. The
+// engine starts the conversation with a handshake:
+//
+//	-> {"type":"hello","version":1}
+//	<- {"type":"hello","name":"ML Momentum","description":"Gradient boosted momentum"}
+//
+// For every symbol and trading cycle it then asks for a signal, sending the
+// bars oldest first and the live price:
+//
+//	-> {"type":"analyze","id":7,"symbol":"AAPL","price":189.2,"bars":[{"t":"2024-05-01T00:00:00Z","o":187.1,"h":190.4,"l":186.9,"c":189.0,"v":51234000}]}
+//	<- {"type":"signal","id":7,"signal":"BUY","strength":0.72,"reason":"20-day return in top decile","indicators":{"ret20":0.081}}
+//
+// The reply's id must match the request. A signal of "HOLD" or "" means no
+// signal, and {"type":"error","id":7,"error":"..."} reports a failure for
+// that request. Fills of orders the strategy contributed to are sent as
+// notifications that expect no reply:
+//
+//	-> {"type":"fill","symbol":"AAPL","side":"buy","quantity":10,"price":189.25,"time":"2024-05-01T14:30:00Z"}
+//
+// On shutdown the engine sends {"type":"shutdown"} and closes stdin. Anything
+// the process writes to stderr is copied to the engine's log.
+package external
+
+import (
+	"time"
+
+	"github.com/MunishMummadi/mock-trade-algorithm/alpaca"
+)
+
+// ProtocolVersion is sent in the handshake so processes can reject engines
+// they don't understand
+const ProtocolVersion = 1
+
+type helloRequest struct {
+	Type    string `json:"type"`
+	Version int    `json:"version"`
+}
+
+type analyzeRequest struct {
+	Type   string    `json:"type"`
+	ID     int64     `json:"id"`
+	Symbol string    `json:"symbol"`
+	Price  float64   `json:"price"`
+	Bars   []wireBar `json:"bars"`
+}
+
+type fillEvent struct {
+	Type     string    `json:"type"`
+	Symbol   string    `json:"symbol"`
+	Side     string    `json:"side"`
+	Quantity float64   `json:"quantity"`
+	Price    float64   `json:"price"`
+	Time     time.Time `json:"time"`
+}
+
+type shutdownRequest struct {
+	Type string `json:"type"`
+}
+
+type wireBar struct {
+	Timestamp time.Time `json:"t"`
+	Open      float64   `json:"o"`
+	High      float64   `json:"h"`
+	Low       float64   `json:"l"`
+	Close     float64   `json:"c"`
+	Volume    int64     `json:"v"`
+}
+
+func toWireBars(bars []alpaca.MockBar) []wireBar {
+	wire := make([]wireBar, len(bars))
+	for i, bar := range bars {
+		wire[i] = wireBar{
+			Timestamp: bar.Timestamp,
+			Open:      bar.Open,
+			High:      bar.High,
+			Low:       bar.Low,
+			Close:     bar.Close,
+			Volume:    bar.Volume,
+		}
+	}
+	return wire
+}
+
+// reply is any message from the process
+type reply struct {
+	Type        string             `json:"type"`
+	ID          int64              `json:"id"`
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	Signal      string             `json:"signal"`
+	Strength    float64            `json:"strength"`
+	Reason      string             `json:"reason"`
+	Indicators  map[string]float64 `json:"indicators"`
+	Error       string             `json:"error"`
+}
@@ -0,0 +1,301 @@
+package external
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/MunishMummadi/mock-trade-algorithm/alpaca"
+	"github.com/MunishMummadi/mock-trade-algorithm/config"
+	"github.com/MunishMummadi/mock-trade-algorithm/models"
+)
+
+// Restarts after a crash back off exponentially between these bounds
+const (
+	minRestartDelay = time.Second
+	maxRestartDelay = time.Minute
+)
+
+// Config describes one external strategy
+type Config struct {
+	Command      []string      // program and arguments
+	Timeout      time.Duration // how long a signal request may take
+	StartTimeout time.Duration // how long the process may take to answer the handshake
+}
+
+// ConfigsFromConfig builds one Config per command in EXTERNAL_STRATEGIES.
+// Commands are separated by semicolons and split into arguments on
+// whitespace, without shell quoting.
+func ConfigsFromConfig(cfg *config.Config) []Config {
+	var configs []Config
+	for _, command := range strings.Split(cfg.ExternalStrategies, ";") {
+		if fields := strings.Fields(command); len(fields) > 0 {
+			configs = append(configs, Config{
+				Command:      fields,
+				Timeout:      cfg.ExternalStrategyTimeout,
+				StartTimeout: cfg.ExternalStrategyStartTimeout,
+			})
+		}
+	}
+	return configs
+}
+
+// Strategy is a strategy implemented by an external process. A process that
+// crashes, times out or breaks the protocol is killed and restarted in the
+// background, backing off while it keeps failing; until then it produces no
+// signals.
+type Strategy struct {
+	config      Config
+	name        string
+	description string
+
+	mu         sync.Mutex
+	proc       *process
+	nextID     int64
+	restarts   int
+	retryDelay time.Duration
+	retryAt    time.Time
+	launching  bool  // a restart is in progress
+	launchErr  error // why the last restart failed, until it is reported
+	closed     bool
+	launches   sync.WaitGroup
+}
+
+// Start launches the process and performs the handshake, which supplies the
+// strategy's name and description
+func Start(cfg Config) (*Strategy, error) {
+	if len(cfg.Command) == 0 {
+		return nil, fmt.Errorf("external strategy has no command")
+	}
+
+	s := &Strategy{config: cfg}
+	proc, name, description, err := s.launch()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start external strategy %q: %w", strings.Join(cfg.Command, " "), err)
+	}
+
+	s.proc = proc
+	s.name = name
+	s.description = description
+	if s.description == "" {
+		s.description = "External strategy: " + strings.Join(cfg.Command, " ")
+	}
+	return s, nil
+}
+
+// launch starts a new process and returns it with its handshake answer
+func (s *Strategy) launch() (*process, string, string, error) {
+	label := s.name
+	if label == "" {
+		label = s.config.Command[0]
+	}
+
+	proc, err := launch(s.config.Command, label)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	hello, err := proc.request(helloRequest{Type: "hello", Version: ProtocolVersion}, "hello", 0, s.config.StartTimeout)
+	if err != nil {
+		proc.kill()
+		return nil, "", "", fmt.Errorf("handshake failed: %w", err)
+	}
+	if strings.TrimSpace(hello.Name) == "" {
+		proc.kill()
+		return nil, "", "", fmt.Errorf("handshake has no strategy name")
+	}
+	return proc, hello.Name, hello.Description, nil
+}
+
+func (s *Strategy) GetName() string {
+	return s.name
+}
+
+func (s *Strategy) GetDescription() string {
+	return s.description
+}
+
+// Analyze implements the Strategy interface
+func (s *Strategy) Analyze(symbol string, bars []alpaca.MockBar, currentPrice decimal.Decimal) *models.TradingSignal {
+	signal, _ := s.AnalyzeChecked(symbol, bars, currentPrice)
+	return signal
+}
+
+// AnalyzeChecked implements the strategies.CheckedStrategy interface. Crashes,
+// timeouts, failed restarts and malformed replies are returned as errors;
+// calls made while the process is restarting produce no signal.
+func (s *Strategy) AnalyzeChecked(symbol string, bars []alpaca.MockBar, currentPrice decimal.Decimal) (*models.TradingSignal, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	proc, err := s.running()
+	if proc == nil {
+		return nil, err
+	}
+
+	s.nextID++
+	request := analyzeRequest{
+		Type:   "analyze",
+		ID:     s.nextID,
+		Symbol: symbol,
+		Price:  currentPrice.InexactFloat64(),
+		Bars:   toWireBars(bars),
+	}
+	r, err := proc.request(request, "signal", request.ID, s.config.Timeout)
+	if err != nil {
+		s.fail(err)
+		return nil, err
+	}
+	s.retryDelay = 0
+
+	signal := strings.ToUpper(strings.TrimSpace(r.Signal))
+	switch signal {
+	case "BUY", "SELL":
+	case "", "HOLD":
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unknown signal %q for %s", r.Signal, symbol)
+	}
+
+	strength := r.Strength
+	if math.IsNaN(strength) {
+		strength = 0
+	}
+
+	return &models.TradingSignal{
+		Symbol:     symbol,
+		Signal:     signal,
+		Strength:   math.Max(0, math.Min(1, strength)),
+		Price:      currentPrice,
+		Strategy:   s.name,
+		CreatedAt:  time.Now(),
+		Indicators: r.Indicators,
+		Reason:     r.Reason,
+	}, nil
+}
+
+// OnFill implements strategies.FillHandler by forwarding the fill
+func (s *Strategy) OnFill(trade *models.Trade) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	proc, _ := s.running()
+	if proc == nil {
+		return
+	}
+
+	event := fillEvent{
+		Type:     "fill",
+		Symbol:   trade.Symbol,
+		Side:     string(trade.Side),
+		Quantity: trade.Quantity.InexactFloat64(),
+		Price:    trade.FillPrice.InexactFloat64(),
+		Time:     trade.UpdatedAt,
+	}
+	if trade.FilledAt != nil {
+		event.Time = *trade.FilledAt
+	}
+	if err := proc.write(event, s.config.Timeout); err != nil {
+		s.fail(err)
+	}
+}
+
+// Close implements strategies.Closer by shutting the process down, waiting
+// for a restart in progress to finish
+func (s *Strategy) Close() error {
+	s.mu.Lock()
+	s.closed = true
+	if s.proc != nil {
+		s.proc.stop(s.config.Timeout)
+		s.proc = nil
+	}
+	s.mu.Unlock()
+
+	s.launches.Wait()
+	return nil
+}
+
+// running returns the live process. When it has died it starts a restart
+// in the background once the restart delay has passed, so no call waits
+// for the handshake; until the restart succeeds it returns nil. The error
+// is the crash or failed restart it found, each reported once.
+func (s *Strategy) running() (*process, error) {
+	if s.proc != nil {
+		select {
+		case <-s.proc.exited:
+			err := fmt.Errorf("process exited")
+			s.fail(err)
+			return nil, err
+		default:
+			return s.proc, nil
+		}
+	}
+
+	if err := s.launchErr; err != nil {
+		s.launchErr = nil
+		return nil, fmt.Errorf("restart failed: %w", err)
+	}
+	if s.launching || s.closed || time.Now().Before(s.retryAt) {
+		return nil, nil
+	}
+
+	s.launching = true
+	s.launches.Add(1)
+	go s.restart()
+	return nil, nil
+}
+
+// restart launches a replacement process without holding the lock
+func (s *Strategy) restart() {
+	defer s.launches.Done()
+
+	proc, name, _, err := s.launch()
+	if err == nil && name != s.name {
+		proc.kill()
+		err = fmt.Errorf("restarted process calls itself %q", name)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.launching = false
+	if s.closed {
+		if proc != nil && err == nil {
+			proc.kill()
+		}
+		return
+	}
+	if err != nil {
+		s.launchErr = err
+		s.fail(err)
+		return
+	}
+
+	s.proc = proc
+	s.restarts++
+	log.Printf("Restarted external strategy %s (restart %d)", s.name, s.restarts)
+}
+
+// fail kills the process and schedules a restart
+func (s *Strategy) fail(err error) {
+	if s.proc != nil {
+		s.proc.kill()
+		s.proc = nil
+	}
+
+	s.retryDelay *= 2
+	if s.retryDelay < minRestartDelay {
+		s.retryDelay = minRestartDelay
+	}
+	if s.retryDelay > maxRestartDelay {
+		s.retryDelay = maxRestartDelay
+	}
+	s.retryAt = time.Now().Add(s.retryDelay)
+
+	log.Printf("Warning: external strategy %s failed: %v; restarting in %s", s.name, err, s.retryDelay)
+}
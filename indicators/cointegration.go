@@ -0,0 +1,113 @@
+package indicators
+
+import "math"
+
+// EngleGrangerCritical5 is the 5% critical value of the Engle-Granger test
+// for two series with a constant (MacKinnon, large sample). ADF statistics
+// below it reject "no cointegration".
+const EngleGrangerCritical5 = -3.34
+
+// Cointegration is the result of an Engle-Granger test of y against x
+type Cointegration struct {
+	Alpha      float64   // intercept of y on x
+	HedgeRatio float64   // slope of y on x
+	ADF        float64   // Dickey-Fuller t-statistic of the residuals
+	Spread     []float64 // residuals y - alpha - hedgeRatio*x, one per input
+}
+
+// Cointegrated reports whether the test rejects "no cointegration" at 5%
+func (c *Cointegration) Cointegrated() bool {
+	return c.ADF < EngleGrangerCritical5
+}
+
+// OLS fits y = alpha + beta*x by ordinary least squares
+func OLS(y, x []float64) (float64, float64, bool) {
+	if len(y) != len(x) || len(y) < 2 {
+		return 0, 0, false
+	}
+
+	meanX, meanY := average(x), average(y)
+	var covariance, variance float64
+	for i := range x {
+		dx := x[i] - meanX
+		covariance += dx * (y[i] - meanY)
+		variance += dx * dx
+	}
+	if variance == 0 {
+		return 0, 0, false
+	}
+
+	beta := covariance / variance
+	return meanY - beta*meanX, beta, true
+}
+
+// EngleGranger runs the two-step Engle-Granger cointegration test: regress y
+// on x, then run a Dickey-Fuller regression (no constant, no lags) on the
+// residuals. It returns nil when the series are too short or degenerate.
+func EngleGranger(y, x []float64) *Cointegration {
+	alpha, beta, ok := OLS(y, x)
+	if !ok || len(y) < 20 {
+		return nil
+	}
+
+	spread := make([]float64, len(y))
+	for i := range y {
+		spread[i] = y[i] - alpha - beta*x[i]
+	}
+
+	adf, ok := dickeyFuller(spread)
+	if !ok {
+		return nil
+	}
+
+	return &Cointegration{Alpha: alpha, HedgeRatio: beta, ADF: adf, Spread: spread}
+}
+
+// ZScore returns how many standard deviations the last value is from the
+// mean of the last period values
+func ZScore(values []float64, period int) (float64, bool) {
+	if period < 2 || len(values) < period {
+		return 0, false
+	}
+
+	window := values[len(values)-period:]
+	mean := average(window)
+	variance := 0.0
+	for _, v := range window {
+		variance += (v - mean) * (v - mean)
+	}
+	std := math.Sqrt(variance / float64(period))
+	if std == 0 {
+		return 0, false
+	}
+	return (values[len(values)-1] - mean) / std, true
+}
+
+// dickeyFuller returns the t-statistic of gamma in d(e_t) = gamma*e_{t-1} + u_t
+func dickeyFuller(e []float64) (float64, bool) {
+	n := len(e) - 1
+	if n < 3 {
+		return 0, false
+	}
+
+	var sumLagSq, sumLagDiff float64
+	for t := 1; t < len(e); t++ {
+		sumLagSq += e[t-1] * e[t-1]
+		sumLagDiff += e[t-1] * (e[t] - e[t-1])
+	}
+	if sumLagSq == 0 {
+		return 0, false
+	}
+	gamma := sumLagDiff / sumLagSq
+
+	var sse float64
+	for t := 1; t < len(e); t++ {
+		u := e[t] - e[t-1] - gamma*e[t-1]
+		sse += u * u
+	}
+	se := math.Sqrt(sse / float64(n-1) / sumLagSq)
+	if se == 0 {
+		return 0, false
+	}
+	return gamma / se, true
+}
@@ -0,0 +1,159 @@
+package indicators_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/MunishMummadi/mock-trade-algorithm/indicators"
+	"github.com/MunishMummadi/mock-trade-algorithm/strategies"
+)
+
+// randomWalk returns n prices starting at start with normally distributed
+// returns of the given volatility, as floats and as decimals
+func randomWalk(rng *rand.Rand, n int, start, volatility float64) ([]float64, []decimal.Decimal) {
+	prices := make([]float64, n)
+	decimals := make([]decimal.Decimal, n)
+	price := start
+	for i := range prices {
+		price *= math.Exp(rng.NormFloat64() * volatility)
+		// Quote to the cent, or to four decimals below a dollar
+		places := int32(2)
+		if price < 1 {
+			places = 4
+		}
+		decimals[i] = decimal.NewFromFloat(price).Round(places)
+		prices[i] = decimals[i].InexactFloat64()
+	}
+	return prices, decimals
+}
+
+func assertWithinTolerance(t *testing.T, name string, got []float64, want []decimal.Decimal, scale float64) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("%s: float64 version returned %d values, decimal %d", name, len(got), len(want))
+	}
+	for i := range want {
+		diff := math.Abs(got[i] - want[i].InexactFloat64())
+		if diff > indicators.FastPathTolerance*scale {
+			t.Fatalf("%s[%d]: float64 %.12g, decimal %s, difference %.3g exceeds %.3g",
+				name, i, got[i], want[i].String(), diff, indicators.FastPathTolerance*scale)
+		}
+	}
+}
+
+// TestFastPathMatchesDecimal checks on random walks at price levels from
+// cents to six figures that the float64 indicators stay within
+// FastPathTolerance of the decimal helpers
+func TestFastPathMatchesDecimal(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+
+	for walk := 0; walk < 20; walk++ {
+		start := math.Pow(10, -1+rng.Float64()*6)
+		volatility := 0.005 + rng.Float64()*0.04
+		prices, decimals := randomWalk(rng, 100+rng.Intn(80), start, volatility)
+		period := 5 + rng.Intn(30)
+
+		// Averages, bands and MACD scale with the price level, RSI with 100
+		level := 0.0
+		for _, price := range prices {
+			level = math.Max(level, price)
+		}
+
+		assertWithinTolerance(t, "SMA", indicators.SMA(prices, period),
+			strategies.CalculateSMA(decimals, period), level)
+		assertWithinTolerance(t, "EMA", indicators.EMA(prices, period),
+			strategies.CalculateEMA(decimals, period), level)
+		assertWithinTolerance(t, "RSI", indicators.RSI(prices, period),
+			strategies.CalculateRSI(decimals, period), 100)
+
+		upper, middle, lower := indicators.BollingerBands(prices, period, 2)
+		wantUpper, wantMiddle, wantLower := strategies.CalculateBollingerBands(decimals, period, 2)
+		assertWithinTolerance(t, "Bollinger upper", upper, wantUpper, level)
+		assertWithinTolerance(t, "Bollinger middle", middle, wantMiddle, level)
+		assertWithinTolerance(t, "Bollinger lower", lower, wantLower, level)
+
+		line, signal, histogram := indicators.MACD(prices, 12, 26, 9)
+		wantLine, wantSignal, wantHistogram := strategies.CalculateMACD(decimals, 12, 26, 9)
+		assertWithinTolerance(t, "MACD line", line, wantLine, level)
+		assertWithinTolerance(t, "MACD signal", signal, wantSignal, level)
+		assertWithinTolerance(t, "MACD histogram", histogram, wantHistogram, level)
+	}
+}
+
+// benchmarkPrices is the engine's daily history window: 100 bars
+func benchmarkPrices() ([]float64, []decimal.Decimal) {
+	return randomWalk(rand.New(rand.NewSource(1)), 100, 150, 0.02)
+}
+
+func BenchmarkSMA(b *testing.B) {
+	prices, decimals := benchmarkPrices()
+	b.Run("float64", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			indicators.SMA(prices, 20)
+		}
+	})
+	b.Run("decimal", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			strategies.CalculateSMA(decimals, 20)
+		}
+	})
+}
+
+func BenchmarkEMA(b *testing.B) {
+	prices, decimals := benchmarkPrices()
+	b.Run("float64", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			indicators.EMA(prices, 20)
+		}
+	})
+	b.Run("decimal", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			strategies.CalculateEMA(decimals, 20)
+		}
+	})
+}
+
+func BenchmarkRSI(b *testing.B) {
+	prices, decimals := benchmarkPrices()
+	b.Run("float64", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			indicators.RSI(prices, 14)
+		}
+	})
+	b.Run("decimal", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			strategies.CalculateRSI(decimals, 14)
+		}
+	})
+}
+
+func BenchmarkBollinger(b *testing.B) {
+	prices, decimals := benchmarkPrices()
+	b.Run("float64", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			indicators.BollingerBands(prices, 20, 2)
+		}
+	})
+	b.Run("decimal", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			strategies.CalculateBollingerBands(decimals, 20, 2)
+		}
+	})
+}
+
+func BenchmarkMACD(b *testing.B) {
+	prices, decimals := benchmarkPrices()
+	b.Run("float64", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			indicators.MACD(prices, 12, 26, 9)
+		}
+	})
+	b.Run("decimal", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			strategies.CalculateMACD(decimals, 12, 26, 9)
+		}
+	})
+}
@@ -0,0 +1,94 @@
+package indicators
+
+import (
+	"math"
+	"time"
+
+	"github.com/MunishMummadi/mock-trade-algorithm/alpaca"
+)
+
+// wilderAverage seeds with the simple average of the first period values and
+// then applies Wilder smoothing: avg = (prev*(period-1) + x) / period
+func wilderAverage(values []float64, period int) []float64 {
+	if len(values) < period {
+		return nil
+	}
+
+	out := make([]float64, len(values)-period+1)
+	out[0] = average(values[:period])
+	for i := period; i < len(values); i++ {
+		out[i-period+1] = (out[i-period]*float64(period-1) + values[i]) / float64(period)
+	}
+	return out
+}
+
+// wilderSum seeds with the sum of the first period values and then applies
+// Wilder's running total: sum = prev - prev/period + x
+func wilderSum(values []float64, period int) []float64 {
+	if len(values) < period {
+		return nil
+	}
+
+	out := make([]float64, len(values)-period+1)
+	for _, v := range values[:period] {
+		out[0] += v
+	}
+	for i := period; i < len(values); i++ {
+		prev := out[i-period]
+		out[i-period+1] = prev - prev/float64(period) + values[i]
+	}
+	return out
+}
+
+func sma(values []float64, period int) []float64 {
+	if period <= 0 || len(values) < period {
+		return nil
+	}
+
+	out := make([]float64, len(values)-period+1)
+	sum := 0.0
+	for i, v := range values {
+		sum += v
+		if i >= period {
+			sum -= values[i-period]
+		}
+		if i >= period-1 {
+			out[i-period+1] = sum / float64(period)
+		}
+	}
+	return out
+}
+
+func average(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+func highLow(bars []alpaca.MockBar) (float64, float64) {
+	highest := math.Inf(-1)
+	lowest := math.Inf(1)
+	for _, bar := range bars {
+		highest = math.Max(highest, bar.High)
+		lowest = math.Min(lowest, bar.Low)
+	}
+	return highest, lowest
+}
+
+func nanSeries(n int) []float64 {
+	series := make([]float64, n)
+	for i := range series {
+		series[i] = math.NaN()
+	}
+	return series
+}
+
+func sessionDate(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,87 @@
+package indicators
+
+import "math"
+
+// minHurstWindow is the smallest window used by the rescaled range analysis;
+// shorter windows give badly biased R/S values
+const minHurstWindow = 8
+
+// Hurst estimates the Hurst exponent of a price series by rescaled range
+// analysis of its log returns. The returns are split into non-overlapping
+// windows of 8, 16, 32, ... returns. Small windows bias R/S upwards, so the
+// Anis-Lloyd expected R/S of independent returns is subtracted: the exponent
+// is 0.5 plus the slope of the log excess R/S against the log window size.
+// Random walks read around 0.5, trending, persistent prices above it and
+// mean-reverting ones below. It returns false for fewer than 64 returns.
+func Hurst(prices []float64) (float64, bool) {
+	returns := make([]float64, 0, len(prices))
+	for i := 1; i < len(prices); i++ {
+		if prices[i-1] <= 0 || prices[i] <= 0 {
+			return 0, false
+		}
+		returns = append(returns, math.Log(prices[i]/prices[i-1]))
+	}
+
+	var logSizes, logRS []float64
+	for size := minHurstWindow; size <= len(returns)/2; size *= 2 {
+		var total float64
+		var windows int
+		for start := 0; start+size <= len(returns); start += size {
+			if rs, ok := rescaledRange(returns[start : start+size]); ok {
+				total += rs
+				windows++
+			}
+		}
+		if windows > 0 {
+			logSizes = append(logSizes, math.Log(float64(size)))
+			logRS = append(logRS, math.Log(total/float64(windows))-math.Log(expectedRescaledRange(size)))
+		}
+	}
+	if len(logSizes) < 3 {
+		return 0, false
+	}
+
+	_, slope, ok := OLS(logRS, logSizes)
+	return 0.5 + slope, ok
+}
+
+// expectedRescaledRange is the Anis-Lloyd expected R/S of n independent
+// normal values
+func expectedRescaledRange(n int) float64 {
+	var sum float64
+	for i := 1; i < n; i++ {
+		sum += math.Sqrt(float64(n-i) / float64(i))
+	}
+
+	size := float64(n)
+	var scale float64
+	if n <= 340 {
+		// Gamma((n-1)/2) / (sqrt(pi) Gamma(n/2)), in logs to avoid overflow
+		a, _ := math.Lgamma((size - 1) / 2)
+		b, _ := math.Lgamma(size / 2)
+		scale = math.Exp(a-b) / math.Sqrt(math.Pi)
+	} else {
+		scale = 1 / math.Sqrt(size*math.Pi/2)
+	}
+	return scale * sum
+}
+
+// rescaledRange returns the range of the cumulative deviations from the mean
+// divided by the standard deviation
+func rescaledRange(values []float64) (float64, bool) {
+	mean := average(values)
+
+	var cumulative, highest, lowest, squares float64
+	for _, v := range values {
+		cumulative += v - mean
+		highest = math.Max(highest, cumulative)
+		lowest = math.Min(lowest, cumulative)
+		squares += (v - mean) * (v - mean)
+	}
+
+	std := math.Sqrt(squares / float64(len(values)))
+	if std == 0 {
+		return 0, false
+	}
+	return (highest - lowest) / std, true
+}
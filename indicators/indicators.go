@@ -0,0 +1,268 @@
+// Package indicators implements technical indicators over OHLCV bars.
+//
+// Series are float64 and, unless noted otherwise, trimmed so that the first
+// element is the first fully warmed-up value and the last element lines up
+// with the last bar, matching the helpers in the strategies package.
+package indicators
+
+import (
+	"math"
+	"time"
+
+	"github.com/MunishMummadi/mock-trade-algorithm/alpaca"
+)
+
+// TrueRange returns the true range of each bar after the first:
+// max(high-low, |high-prevClose|, |low-prevClose|)
+func TrueRange(bars []alpaca.MockBar) []float64 {
+	if len(bars) < 2 {
+		return nil
+	}
+
+	tr := make([]float64, len(bars)-1)
+	for i := 1; i < len(bars); i++ {
+		prevClose := bars[i-1].Close
+		tr[i-1] = math.Max(bars[i].High-bars[i].Low,
+			math.Max(math.Abs(bars[i].High-prevClose), math.Abs(bars[i].Low-prevClose)))
+	}
+	return tr
+}
+
+// ATR calculates Wilder's Average True Range. The first value is the simple
+// average of the first period true ranges; later values use Wilder smoothing.
+func ATR(bars []alpaca.MockBar, period int) []float64 {
+	tr := TrueRange(bars)
+	if period <= 0 || len(tr) < period {
+		return nil
+	}
+	return wilderAverage(tr, period)
+}
+
+// Stochastic calculates the stochastic oscillator. %K compares the close with
+// the high-low range of the last kPeriod bars; %D is the dPeriod SMA of %K.
+func Stochastic(bars []alpaca.MockBar, kPeriod, dPeriod int) ([]float64, []float64) {
+	if kPeriod <= 0 || dPeriod <= 0 || len(bars) < kPeriod+dPeriod-1 {
+		return nil, nil
+	}
+
+	k := make([]float64, len(bars)-kPeriod+1)
+	for i := kPeriod - 1; i < len(bars); i++ {
+		highest, lowest := highLow(bars[i-kPeriod+1 : i+1])
+		if highest == lowest {
+			k[i-kPeriod+1] = 50
+			continue
+		}
+		k[i-kPeriod+1] = 100 * (bars[i].Close - lowest) / (highest - lowest)
+	}
+
+	return k, sma(k, dPeriod)
+}
+
+// ADX calculates Wilder's Directional Movement Index. It returns ADX, +DI
+// and -DI; the DI series start period-1 bars earlier than ADX.
+func ADX(bars []alpaca.MockBar, period int) ([]float64, []float64, []float64) {
+	if period <= 0 || len(bars) < 2*period {
+		return nil, nil, nil
+	}
+
+	n := len(bars) - 1
+	tr := TrueRange(bars)
+	plusDM := make([]float64, n)
+	minusDM := make([]float64, n)
+
+	for i := 1; i < len(bars); i++ {
+		up := bars[i].High - bars[i-1].High
+		down := bars[i-1].Low - bars[i].Low
+		if up > down && up > 0 {
+			plusDM[i-1] = up
+		}
+		if down > up && down > 0 {
+			minusDM[i-1] = down
+		}
+	}
+
+	smoothTR := wilderSum(tr, period)
+	smoothPlus := wilderSum(plusDM, period)
+	smoothMinus := wilderSum(minusDM, period)
+
+	plusDI := make([]float64, len(smoothTR))
+	minusDI := make([]float64, len(smoothTR))
+	dx := make([]float64, len(smoothTR))
+
+	for i := range smoothTR {
+		if smoothTR[i] == 0 {
+			continue
+		}
+		plusDI[i] = 100 * smoothPlus[i] / smoothTR[i]
+		minusDI[i] = 100 * smoothMinus[i] / smoothTR[i]
+		if total := plusDI[i] + minusDI[i]; total > 0 {
+			dx[i] = 100 * math.Abs(plusDI[i]-minusDI[i]) / total
+		}
+	}
+
+	return wilderAverage(dx, period), plusDI, minusDI
+}
+
+// OBV calculates On-Balance Volume, starting from zero at the first bar
+func OBV(bars []alpaca.MockBar) []float64 {
+	if len(bars) == 0 {
+		return nil
+	}
+
+	obv := make([]float64, len(bars))
+	for i := 1; i < len(bars); i++ {
+		obv[i] = obv[i-1]
+		if bars[i].Close > bars[i-1].Close {
+			obv[i] += float64(bars[i].Volume)
+		} else if bars[i].Close < bars[i-1].Close {
+			obv[i] -= float64(bars[i].Volume)
+		}
+	}
+	return obv
+}
+
+// CCI calculates the Commodity Channel Index using Lambert's 0.015 constant
+func CCI(bars []alpaca.MockBar, period int) []float64 {
+	if period <= 0 || len(bars) < period {
+		return nil
+	}
+
+	typical := TypicalPrices(bars)
+	cci := make([]float64, len(bars)-period+1)
+
+	for i := period - 1; i < len(bars); i++ {
+		window := typical[i-period+1 : i+1]
+		mean := average(window)
+
+		deviation := 0.0
+		for _, tp := range window {
+			deviation += math.Abs(tp - mean)
+		}
+		deviation /= float64(period)
+
+		if deviation == 0 {
+			continue
+		}
+		cci[i-period+1] = (typical[i] - mean) / (0.015 * deviation)
+	}
+	return cci
+}
+
+// WilliamsR calculates Williams %R, ranging from -100 (at the low of the
+// window) to 0 (at the high)
+func WilliamsR(bars []alpaca.MockBar, period int) []float64 {
+	if period <= 0 || len(bars) < period {
+		return nil
+	}
+
+	wr := make([]float64, len(bars)-period+1)
+	for i := period - 1; i < len(bars); i++ {
+		highest, lowest := highLow(bars[i-period+1 : i+1])
+		if highest == lowest {
+			wr[i-period+1] = -50
+			continue
+		}
+		wr[i-period+1] = -100 * (highest - bars[i].Close) / (highest - lowest)
+	}
+	return wr
+}
+
+// IchimokuCloud holds the five Ichimoku lines. Unlike the other indicators
+// every series has one value per bar, with NaN where a line is undefined, so
+// the forward and backward displacements stay aligned with the bars.
+type IchimokuCloud struct {
+	Tenkan  []float64 // conversion line
+	Kijun   []float64 // base line
+	SenkouA []float64 // leading span A, plotted kijun bars ahead
+	SenkouB []float64 // leading span B, plotted kijun bars ahead
+	Chikou  []float64 // lagging span, the close plotted kijun bars behind
+}
+
+// Ichimoku calculates the Ichimoku Kinko Hyo lines; the classic settings
+// are 9, 26 and 52
+func Ichimoku(bars []alpaca.MockBar, tenkanPeriod, kijunPeriod, senkouBPeriod int) *IchimokuCloud {
+	n := len(bars)
+	cloud := &IchimokuCloud{
+		Tenkan:  nanSeries(n),
+		Kijun:   nanSeries(n),
+		SenkouA: nanSeries(n),
+		SenkouB: nanSeries(n),
+		Chikou:  nanSeries(n),
+	}
+
+	midpoint := func(end, period int) float64 {
+		if period <= 0 || end < period-1 {
+			return math.NaN()
+		}
+		highest, lowest := highLow(bars[end-period+1 : end+1])
+		return (highest + lowest) / 2
+	}
+
+	for i := 0; i < n; i++ {
+		cloud.Tenkan[i] = midpoint(i, tenkanPeriod)
+		cloud.Kijun[i] = midpoint(i, kijunPeriod)
+
+		if i+kijunPeriod < n {
+			cloud.Chikou[i] = bars[i+kijunPeriod].Close
+		}
+
+		if source := i - kijunPeriod; source >= 0 {
+			cloud.SenkouA[i] = (midpoint(source, tenkanPeriod) + midpoint(source, kijunPeriod)) / 2
+			cloud.SenkouB[i] = midpoint(source, senkouBPeriod)
+		}
+	}
+
+	return cloud
+}
+
+// VWAP calculates the session volume-weighted average price using the
+// typical price of each bar. The running totals reset at the start of each
+// calendar day, so with daily bars every value equals that bar's typical
+// price.
+func VWAP(bars []alpaca.MockBar) []float64 {
+	if len(bars) == 0 {
+		return nil
+	}
+
+	vwap := make([]float64, len(bars))
+	var cumulativePV, cumulativeVolume float64
+	var session time.Time
+
+	for i, bar := range bars {
+		day := sessionDate(bar.Timestamp)
+		if i == 0 || !day.Equal(session) {
+			session = day
+			cumulativePV = 0
+			cumulativeVolume = 0
+		}
+
+		typical := (bar.High + bar.Low + bar.Close) / 3
+		cumulativePV += typical * float64(bar.Volume)
+		cumulativeVolume += float64(bar.Volume)
+
+		if cumulativeVolume == 0 {
+			vwap[i] = typical
+			continue
+		}
+		vwap[i] = cumulativePV / cumulativeVolume
+	}
+	return vwap
+}
+
+// TypicalPrices returns (high + low + close) / 3 for each bar
+func TypicalPrices(bars []alpaca.MockBar) []float64 {
+	typical := make([]float64, len(bars))
+	for i, bar := range bars {
+		typical[i] = (bar.High + bar.Low + bar.Close) / 3
+	}
+	return typical
+}
+
+// Closes extracts closing prices from bars
+func Closes(bars []alpaca.MockBar) []float64 {
+	closes := make([]float64, len(bars))
+	for i, bar := range bars {
+		closes[i] = bar.Close
+	}
+	return closes
+}
@@ -0,0 +1,255 @@
+package indicators
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/MunishMummadi/mock-trade-algorithm/alpaca"
+)
+
+// tolerance is the absolute difference allowed against the worked examples,
+// which are rounded to six decimals
+const tolerance = 1e-4
+
+var nan = math.NaN()
+
+// referenceBars are twelve daily bars used by the worked examples below.
+// Each bar opens at the previous close.
+func referenceBars() []alpaca.MockBar {
+	highs := []float64{10.0, 10.5, 10.8, 10.7, 10.4, 10.9, 11.5, 11.4, 11.2, 10.9, 11.6, 11.8}
+	lows := []float64{9.0, 9.4, 10.0, 9.9, 9.6, 9.8, 10.6, 10.9, 10.3, 10.1, 10.6, 11.2}
+	closes := []float64{9.5, 10.2, 10.6, 10.0, 9.8, 10.8, 11.3, 11.0, 10.4, 10.7, 11.5, 11.3}
+	volumes := []int64{1000, 1200, 900, 1500, 1100, 1300, 1700, 800, 1400, 1000, 1600, 1200}
+
+	start := time.Date(2026, 3, 2, 16, 0, 0, 0, time.UTC)
+	bars := make([]alpaca.MockBar, len(closes))
+	for i := range bars {
+		open := closes[0]
+		if i > 0 {
+			open = closes[i-1]
+		}
+		bars[i] = alpaca.MockBar{
+			Timestamp: start.AddDate(0, 0, i),
+			Open:      open,
+			High:      highs[i],
+			Low:       lows[i],
+			Close:     closes[i],
+			Volume:    volumes[i],
+		}
+	}
+	return bars
+}
+
+func assertSeries(t *testing.T, name string, got, want []float64) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("%s: got %d values, want %d: %v", name, len(got), len(want), got)
+	}
+	for i := range want {
+		if math.IsNaN(want[i]) {
+			if !math.IsNaN(got[i]) {
+				t.Errorf("%s[%d] = %.6f, want NaN", name, i, got[i])
+			}
+			continue
+		}
+		if math.IsNaN(got[i]) || math.Abs(got[i]-want[i]) > tolerance {
+			t.Errorf("%s[%d] = %.6f, want %.6f", name, i, got[i], want[i])
+		}
+	}
+}
+
+func TestIndicatorsAgainstWorkedExamples(t *testing.T) {
+	bars := referenceBars()
+	stochK, stochD := Stochastic(bars, 5, 3)
+	adx, plusDI, minusDI := ADX(bars, 3)
+
+	tests := []struct {
+		name string
+		got  []float64
+		want []float64
+	}{
+		{
+			// Bar 1: max(10.5-9.4, |10.5-9.5|, |9.4-9.5|) = 1.1
+			name: "TrueRange",
+			got:  TrueRange(bars),
+			want: []float64{1.1, 0.8, 0.8, 0.8, 1.1, 0.9, 0.5, 0.9, 0.8, 1.0, 0.6},
+		},
+		{
+			// Seed (1.1+0.8+0.8)/3 = 0.9, then (0.9*2+0.8)/3 = 0.866667
+			name: "ATR(3)",
+			got:  ATR(bars, 3),
+			want: []float64{0.9, 0.866667, 0.944444, 0.92963, 0.78642, 0.82428, 0.816187, 0.877458, 0.784972},
+		},
+		{
+			// Bars 0-4: range 9.0-10.8, close 9.8 -> 100*0.8/1.8 = 44.444444
+			name: "Stochastic %K(5)",
+			got:  stochK,
+			want: []float64{44.444444, 93.333333, 89.473684, 73.684211, 42.105263, 52.941176, 93.333333, 70.588235},
+		},
+		{
+			name: "Stochastic %D(3)",
+			got:  stochD,
+			want: []float64{75.750487, 85.497076, 68.421053, 56.24355, 62.793258, 72.287582},
+		},
+		{
+			// +DM 0.5+0.3+0 = 0.8 over TR 2.7 -> 29.62963
+			name: "+DI(3)",
+			got:  plusDI,
+			want: []float64{29.62963, 20.512821, 30.196078, 41.965471, 33.07169, 21.035114, 14.162465, 35.374327, 34.85431},
+		},
+		{
+			// -DM 0+0+0.1 = 0.1 over TR 2.7 -> 3.703704
+			name: "-DI(3)",
+			got:  minusDI,
+			want: []float64{3.703704, 14.102564, 8.627451, 5.843293, 4.604919, 27.192545, 26.47619, 16.418273, 12.235124},
+		},
+		{
+			name: "ADX(3)",
+			got:  adx,
+			want: []float64{50.617284, 58.930041, 64.471879, 47.237061, 41.591549, 39.927675, 42.629959},
+		},
+		{
+			name: "OBV",
+			got:  OBV(bars),
+			want: []float64{0, 1200, 2100, 600, -500, 800, 2500, 1700, 300, 1300, 2900, 1700},
+		},
+		{
+			// Typical prices of bars 0-4 average 10.026667 with mean deviation
+			// 0.248; bar 4 typical 9.933333 -> (9.933333-10.026667)/(0.015*0.248)
+			name: "CCI(5)",
+			got:  CCI(bars, 5),
+			want: []float64{-25.089606, 88.744589, 150.584795, 80.777096, -4.86618, -55.555556, 75.0, 93.220339},
+		},
+		{
+			// Bars 0-4: -100*(10.8-9.8)/1.8 = -55.555556
+			name: "Williams %R(5)",
+			got:  WilliamsR(bars, 5),
+			want: []float64{-55.555556, -6.666667, -10.526316, -26.315789, -57.894737, -47.058824, -6.666667, -29.411765},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertSeries(t, tt.name, tt.got, tt.want)
+		})
+	}
+}
+
+func TestIchimokuAgainstWorkedExample(t *testing.T) {
+	cloud := Ichimoku(referenceBars(), 2, 3, 5)
+	if cloud == nil {
+		t.Fatal("Ichimoku returned nil")
+	}
+
+	tests := []struct {
+		name string
+		got  []float64
+		want []float64
+	}{
+		{
+			// Bars 0-1: (10.5+9.0)/2 = 9.75
+			name: "Tenkan(2)",
+			got:  cloud.Tenkan,
+			want: []float64{nan, 9.75, 10.1, 10.35, 10.15, 10.25, 10.65, 11.05, 10.85, 10.65, 10.85, 11.2},
+		},
+		{
+			name: "Kijun(3)",
+			got:  cloud.Kijun,
+			want: []float64{nan, nan, 9.9, 10.1, 10.2, 10.25, 10.55, 10.65, 10.9, 10.75, 10.85, 10.95},
+		},
+		{
+			// Bar 5 plots bar 2's (10.1+9.9)/2, displaced by the kijun period
+			name: "Senkou A",
+			got:  cloud.SenkouA,
+			want: []float64{nan, nan, nan, nan, nan, 10.0, 10.225, 10.175, 10.25, 10.6, 10.85, 10.875},
+		},
+		{
+			// Bar 7 plots bars 0-4's (10.8+9.0)/2
+			name: "Senkou B(5)",
+			got:  cloud.SenkouB,
+			want: []float64{nan, nan, nan, nan, nan, nan, nan, 9.9, 10.15, 10.55, 10.55, 10.55},
+		},
+		{
+			// Bar i plots the close kijun bars later
+			name: "Chikou",
+			got:  cloud.Chikou,
+			want: []float64{10.0, 9.8, 10.8, 11.3, 11.0, 10.4, 10.7, 11.5, 11.3, nan, nan, nan},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertSeries(t, tt.name, tt.got, tt.want)
+		})
+	}
+}
+
+func TestVWAPResetsEachSession(t *testing.T) {
+	day1 := time.Date(2026, 3, 2, 9, 30, 0, 0, time.Local)
+	day2 := day1.AddDate(0, 0, 1)
+	bars := []alpaca.MockBar{
+		{Timestamp: day1, High: 10.2, Low: 9.8, Close: 10.0, Volume: 100},
+		{Timestamp: day1.Add(5 * time.Minute), High: 10.4, Low: 10.0, Close: 10.3, Volume: 200},
+		{Timestamp: day1.Add(10 * time.Minute), High: 10.5, Low: 10.1, Close: 10.2, Volume: 300},
+		{Timestamp: day2, High: 20.4, Low: 19.6, Close: 20.0, Volume: 50},
+		{Timestamp: day2.Add(5 * time.Minute), High: 20.9, Low: 20.3, Close: 20.6, Volume: 150},
+	}
+
+	// Day 1: (10*100 + 10.233333*200) / 300 = 10.155556; day 2 starts over
+	// at its first typical price 20.0
+	want := []float64{10.0, 10.155556, 10.211111, 20.0, 20.45}
+	assertSeries(t, "VWAP", VWAP(bars), want)
+}
+
+func TestIndicatorsNeedEnoughBars(t *testing.T) {
+	bars := referenceBars()[:4]
+
+	if got := ATR(bars, 5); got != nil {
+		t.Errorf("ATR with 3 true ranges and period 5 = %v, want nil", got)
+	}
+	if k, d := Stochastic(bars, 3, 3); k != nil || d != nil {
+		t.Errorf("Stochastic with 4 bars and periods 3,3 = %v, %v, want nil", k, d)
+	}
+	if got := WilliamsR(bars, 5); got != nil {
+		t.Errorf("WilliamsR with 4 bars and period 5 = %v, want nil", got)
+	}
+	if got := CCI(bars, 5); got != nil {
+		t.Errorf("CCI with 4 bars and period 5 = %v, want nil", got)
+	}
+}
+
+// TestHurstIsUnbiased checks that the bias-corrected Hurst exponent centres
+// random walks of the engine's history length on 0.5, with persistent and
+// anti-persistent returns on either side
+func TestHurstIsUnbiased(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	meanHurst := func(autocorrelation float64) float64 {
+		var total float64
+		var walks int
+		for walk := 0; walk < 500; walk++ {
+			prices := []float64{100}
+			var r float64
+			for i := 1; i < 100; i++ {
+				r = autocorrelation*r + rng.NormFloat64()*0.01
+				prices = append(prices, prices[i-1]*math.Exp(r))
+			}
+			if h, ok := Hurst(prices); ok {
+				total += h
+				walks++
+			}
+		}
+		return total / float64(walks)
+	}
+
+	if h := meanHurst(0); math.Abs(h-0.5) > 0.03 {
+		t.Errorf("random walks: mean Hurst %.3f, want about 0.5", h)
+	}
+	if h := meanHurst(0.3); h < 0.55 {
+		t.Errorf("persistent returns: mean Hurst %.3f, want above 0.55", h)
+	}
+	if h := meanHurst(-0.3); h > 0.45 {
+		t.Errorf("anti-persistent returns: mean Hurst %.3f, want below 0.45", h)
+	}
+}
@@ -0,0 +1,327 @@
+package indicators
+
+import (
+	"math"
+
+	"github.com/MunishMummadi/mock-trade-algorithm/alpaca"
+)
+
+// The streaming indicators below keep running state so each new value is
+// folded in with O(1) work instead of recomputing over the whole history.
+// After warm-up they produce the same values as their batch counterparts,
+// up to floating point rounding.
+
+// window is a fixed-size ring buffer of the most recent values
+type window struct {
+	values []float64
+	next   int
+	count  int
+}
+
+func newWindow(size int) *window {
+	return &window{values: make([]float64, size)}
+}
+
+// push stores v and returns the value it evicted, if the window was full
+func (w *window) push(v float64) (float64, bool) {
+	evicted, full := w.values[w.next], w.count == len(w.values)
+	w.values[w.next] = v
+	w.next = (w.next + 1) % len(w.values)
+	if !full {
+		w.count++
+	}
+	return evicted, full
+}
+
+func (w *window) clone() *window {
+	c := *w
+	c.values = append([]float64(nil), w.values...)
+	return &c
+}
+
+// StreamingSMA is a simple moving average maintained with a running sum
+type StreamingSMA struct {
+	period int
+	window *window
+	sum    float64
+}
+
+func NewStreamingSMA(period int) *StreamingSMA {
+	return &StreamingSMA{period: period, window: newWindow(period)}
+}
+
+func (s *StreamingSMA) Update(v float64) float64 {
+	if evicted, full := s.window.push(v); full {
+		s.sum -= evicted
+	}
+	s.sum += v
+	return s.Value()
+}
+
+func (s *StreamingSMA) Ready() bool {
+	return s.window.count == s.period
+}
+
+func (s *StreamingSMA) Value() float64 {
+	if s.window.count == 0 {
+		return 0
+	}
+	return s.sum / float64(s.window.count)
+}
+
+// Clone returns an independent copy of the average
+func (s *StreamingSMA) Clone() *StreamingSMA {
+	c := *s
+	c.window = s.window.clone()
+	return &c
+}
+
+// StreamingEMA is an exponential moving average seeded with the SMA of its
+// first period values, matching strategies.CalculateEMA
+type StreamingEMA struct {
+	period     int
+	multiplier float64
+	seed       *StreamingSMA
+	value      float64
+	ready      bool
+}
+
+func NewStreamingEMA(period int) *StreamingEMA {
+	return &StreamingEMA{
+		period:     period,
+		multiplier: 2.0 / float64(period+1),
+		seed:       NewStreamingSMA(period),
+	}
+}
+
+func (e *StreamingEMA) Update(v float64) float64 {
+	if !e.ready {
+		e.value = e.seed.Update(v)
+		e.ready = e.seed.Ready()
+		return e.value
+	}
+	e.value = v*e.multiplier + e.value*(1-e.multiplier)
+	return e.value
+}
+
+func (e *StreamingEMA) Ready() bool {
+	return e.ready
+}
+
+func (e *StreamingEMA) Value() float64 {
+	return e.value
+}
+
+// Clone returns an independent copy of the average
+func (e *StreamingEMA) Clone() *StreamingEMA {
+	c := *e
+	c.seed = e.seed.Clone()
+	return &c
+}
+
+// StreamingRSI is Wilder's RSI, seeded with the simple average gain and loss
+// of the first period price changes
+type StreamingRSI struct {
+	period    int
+	prev      float64
+	hasPrev   bool
+	changes   int
+	avgGain   float64
+	avgLoss   float64
+	value     float64
+	prevValue float64
+}
+
+func NewStreamingRSI(period int) *StreamingRSI {
+	return &StreamingRSI{period: period}
+}
+
+func (r *StreamingRSI) Update(price float64) float64 {
+	if !r.hasPrev {
+		r.prev = price
+		r.hasPrev = true
+		return r.value
+	}
+
+	change := price - r.prev
+	r.prev = price
+	gain, loss := math.Max(change, 0), math.Max(-change, 0)
+	r.changes++
+
+	period := float64(r.period)
+	if r.changes <= r.period {
+		// Accumulate the seed averages
+		r.avgGain += gain / period
+		r.avgLoss += loss / period
+		if r.changes < r.period {
+			return r.value
+		}
+	} else {
+		r.avgGain = (r.avgGain*(period-1) + gain) / period
+		r.avgLoss = (r.avgLoss*(period-1) + loss) / period
+	}
+
+	r.prevValue = r.value
+	if r.avgLoss == 0 {
+		r.value = 100
+	} else {
+		r.value = 100 - 100/(1+r.avgGain/r.avgLoss)
+	}
+	return r.value
+}
+
+func (r *StreamingRSI) Ready() bool {
+	return r.changes >= r.period
+}
+
+// HasPrevious reports whether two RSI values are available
+func (r *StreamingRSI) HasPrevious() bool {
+	return r.changes > r.period
+}
+
+func (r *StreamingRSI) Value() float64 {
+	return r.value
+}
+
+// Previous returns the RSI value before the latest update
+func (r *StreamingRSI) Previous() float64 {
+	return r.prevValue
+}
+
+// Clone returns an independent copy of the RSI
+func (r *StreamingRSI) Clone() *StreamingRSI {
+	c := *r
+	return &c
+}
+
+// RollingStats maintains the mean and population standard deviation of the
+// last period values from running sums of values and squares
+type RollingStats struct {
+	period int
+	window *window
+	sum    float64
+	sumSq  float64
+}
+
+func NewRollingStats(period int) *RollingStats {
+	return &RollingStats{period: period, window: newWindow(period)}
+}
+
+func (r *RollingStats) Update(v float64) {
+	if evicted, full := r.window.push(v); full {
+		r.sum -= evicted
+		r.sumSq -= evicted * evicted
+	}
+	r.sum += v
+	r.sumSq += v * v
+}
+
+func (r *RollingStats) Ready() bool {
+	return r.window.count == r.period
+}
+
+func (r *RollingStats) Mean() float64 {
+	if r.window.count == 0 {
+		return 0
+	}
+	return r.sum / float64(r.window.count)
+}
+
+func (r *RollingStats) StdDev() float64 {
+	if r.window.count == 0 {
+		return 0
+	}
+	mean := r.Mean()
+	variance := r.sumSq/float64(r.window.count) - mean*mean
+	if variance < 0 {
+		// Guard against cancellation when values are nearly constant
+		variance = 0
+	}
+	return math.Sqrt(variance)
+}
+
+// Clone returns an independent copy of the statistics
+func (r *RollingStats) Clone() *RollingStats {
+	c := *r
+	c.window = r.window.clone()
+	return &c
+}
+
+// StreamingBollinger tracks Bollinger Bands over a rolling window
+type StreamingBollinger struct {
+	stats     *RollingStats
+	deviation float64
+}
+
+func NewStreamingBollinger(period int, stdDev float64) *StreamingBollinger {
+	return &StreamingBollinger{stats: NewRollingStats(period), deviation: stdDev}
+}
+
+func (b *StreamingBollinger) Update(v float64) {
+	b.stats.Update(v)
+}
+
+func (b *StreamingBollinger) Ready() bool {
+	return b.stats.Ready()
+}
+
+// Bands returns the upper, middle and lower bands
+func (b *StreamingBollinger) Bands() (float64, float64, float64) {
+	middle := b.stats.Mean()
+	offset := b.deviation * b.stats.StdDev()
+	return middle + offset, middle, middle - offset
+}
+
+// Clone returns an independent copy of the bands
+func (b *StreamingBollinger) Clone() *StreamingBollinger {
+	return &StreamingBollinger{stats: b.stats.Clone(), deviation: b.deviation}
+}
+
+// StreamingATR is Wilder's Average True Range
+type StreamingATR struct {
+	period    int
+	prevClose float64
+	hasPrev   bool
+	seed      *StreamingSMA
+	value     float64
+	ready     bool
+}
+
+func NewStreamingATR(period int) *StreamingATR {
+	return &StreamingATR{period: period, seed: NewStreamingSMA(period)}
+}
+
+func (a *StreamingATR) Update(bar alpaca.MockBar) float64 {
+	if !a.hasPrev {
+		a.prevClose = bar.Close
+		a.hasPrev = true
+		return a.value
+	}
+
+	tr := math.Max(bar.High-bar.Low,
+		math.Max(math.Abs(bar.High-a.prevClose), math.Abs(bar.Low-a.prevClose)))
+	a.prevClose = bar.Close
+
+	if !a.ready {
+		a.value = a.seed.Update(tr)
+		a.ready = a.seed.Ready()
+		return a.value
+	}
+	a.value = (a.value*float64(a.period-1) + tr) / float64(a.period)
+	return a.value
+}
+
+func (a *StreamingATR) Ready() bool {
+	return a.ready
+}
+
+func (a *StreamingATR) Value() float64 {
+	return a.value
+}
+
+// Clone returns an independent copy of the ATR
+func (a *StreamingATR) Clone() *StreamingATR {
+	c := *a
+	c.seed = a.seed.Clone()
+	return &c
+}
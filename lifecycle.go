@@ -0,0 +1,187 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/MunishMummadi/mock-trade-algorithm/alpaca"
+	"github.com/MunishMummadi/mock-trade-algorithm/models"
+	"github.com/MunishMummadi/mock-trade-algorithm/strategies"
+)
+
+// startStrategies restores persisted state and runs Init for every strategy
+// that implements the optional lifecycle hooks
+func (e *TradingEngine) startStrategies() error {
+	for _, strategy := range e.allStrategies() {
+		if stateful, ok := strategy.(strategies.Stateful); ok {
+			state, err := e.db.LoadStrategyState(strategy.GetName())
+			if err != nil {
+				return err
+			}
+			if state != nil {
+				if err := stateful.RestoreState(state); err != nil {
+					return fmt.Errorf("failed to restore state for %s: %w", strategy.GetName(), err)
+				}
+				log.Printf("Restored state for strategy %s", strategy.GetName())
+			}
+		}
+
+		if initializer, ok := strategy.(strategies.Initializer); ok {
+			if err := initializer.Init(); err != nil {
+				return fmt.Errorf("failed to initialize %s: %w", strategy.GetName(), err)
+			}
+		}
+	}
+	return nil
+}
+
+// saveStrategyStates persists the state of every Stateful strategy
+func (e *TradingEngine) saveStrategyStates() {
+	for _, strategy := range e.allStrategies() {
+		stateful, ok := strategy.(strategies.Stateful)
+		if !ok {
+			continue
+		}
+
+		var state []byte
+		err := e.supervisor.Run(context.Background(), strategy.GetName(), func() error {
+			var err error
+			state, err = stateful.SaveState()
+			return err
+		})
+		if err != nil {
+			log.Printf("Warning: failed to save state for %s: %v", strategy.GetName(), err)
+			continue
+		}
+		if err := e.db.SaveStrategyState(strategy.GetName(), state); err != nil {
+			log.Printf("Warning: %v", err)
+		}
+	}
+}
+
+// stopStrategies releases the resources of every Closer strategy
+func (e *TradingEngine) stopStrategies() {
+	for _, strategy := range e.allStrategies() {
+		if closer, ok := strategy.(strategies.Closer); ok {
+			if err := closer.Close(); err != nil {
+				log.Printf("Warning: failed to stop %s: %v", strategy.GetName(), err)
+			}
+		}
+	}
+}
+
+// dispatchBars delivers the symbol's completed bars to every BarHandler.
+// Bars are keyed by their trading day, since the same session can come back
+// with a different timestamp, and a day is only delivered once it has ended.
+// Each strategy's position is kept separately and only moves on once the
+// strategy has handled its bars, so a strategy that was busy, quarantined or
+// timed out gets them again next cycle.
+func (e *TradingEngine) dispatchBars(ctx context.Context, symbol string, bars []alpaca.MockBar) {
+	bySymbol, exists := e.lastBars[symbol]
+	if !exists {
+		bySymbol = make(map[string]time.Time)
+		e.lastBars[symbol] = bySymbol
+	}
+
+	now := time.Now()
+	for _, strategy := range e.allStrategies() {
+		handler, ok := strategy.(strategies.BarHandler)
+		if !ok {
+			continue
+		}
+
+		lastDay := bySymbol[strategy.GetName()]
+		var fresh []alpaca.MockBar
+		for _, bar := range bars {
+			day := strategies.TimeframeDay.Start(bar.Timestamp)
+			if day.After(lastDay) && !day.AddDate(0, 0, 1).After(now) {
+				fresh = append(fresh, bar)
+				lastDay = day
+			}
+		}
+		if len(fresh) == 0 {
+			continue
+		}
+
+		err := e.supervisor.Run(ctx, strategy.GetName(), func() error {
+			for _, bar := range fresh {
+				handler.OnBar(symbol, bar)
+			}
+			return nil
+		})
+		if err == nil {
+			bySymbol[strategy.GetName()] = lastDay
+		}
+	}
+}
+
+// notifyOrderUpdate tells the strategies whose signals agreed with the trade
+// about its status, and about the fill if it filled
+func (e *TradingEngine) notifyOrderUpdate(trade *models.Trade, signals []*models.TradingSignal) {
+	// Orders that failed validation never reached the broker
+	if trade.ID == 0 {
+		return
+	}
+
+	side := signalSide(trade.Side)
+	for _, signal := range signals {
+		if signal.Signal != side {
+			continue
+		}
+		e.notifyStrategy(e.strategyByName(signal.Strategy), trade)
+	}
+}
+
+// notifyStrategy delivers an order update, and the fill if it filled, to a
+// single strategy
+func (e *TradingEngine) notifyStrategy(strategy strategies.Describer, trade *models.Trade) {
+	if strategy == nil {
+		return
+	}
+	_, updates := strategy.(strategies.OrderUpdateHandler)
+	_, fills := strategy.(strategies.FillHandler)
+	if !updates && !fills {
+		return
+	}
+
+	e.supervisor.Run(context.Background(), strategy.GetName(), func() error {
+		if handler, ok := strategy.(strategies.OrderUpdateHandler); ok {
+			handler.OnOrderUpdate(trade)
+		}
+		if handler, ok := strategy.(strategies.FillHandler); ok && trade.Status == models.TradeStatusFilled {
+			handler.OnFill(trade)
+		}
+		return nil
+	})
+}
+
+func (e *TradingEngine) strategyByName(name string) strategies.Describer {
+	for _, strategy := range e.allStrategies() {
+		if strategy.GetName() == name {
+			return strategy
+		}
+	}
+	return nil
+}
+
+// allStrategies returns per-symbol and cross-sectional strategies together,
+// including those running in shadow mode
+func (e *TradingEngine) allStrategies() []strategies.Describer {
+	all := make([]strategies.Describer, 0, len(e.strategies)+len(e.universeStrategies)+
+		len(e.shadowStrategies)+len(e.shadowUniverse))
+	for _, strategy := range e.strategies {
+		all = append(all, strategy)
+	}
+	for _, strategy := range e.universeStrategies {
+		all = append(all, strategy)
+	}
+	for _, strategy := range e.shadowStrategies {
+		all = append(all, strategy)
+	}
+	for _, strategy := range e.shadowUniverse {
+		all = append(all, strategy)
+	}
+	return all
+}
@@ -2,32 +2,69 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"log"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/shopspring/decimal"
 
+	"github.com/MunishMummadi/mock-trade-algorithm/aggregation"
 	"github.com/MunishMummadi/mock-trade-algorithm/alpaca"
+	"github.com/MunishMummadi/mock-trade-algorithm/analytics"
+	"github.com/MunishMummadi/mock-trade-algorithm/calibration"
 	"github.com/MunishMummadi/mock-trade-algorithm/config"
+	"github.com/MunishMummadi/mock-trade-algorithm/corpactions"
 	"github.com/MunishMummadi/mock-trade-algorithm/database"
+	"github.com/MunishMummadi/mock-trade-algorithm/external"
 	"github.com/MunishMummadi/mock-trade-algorithm/models"
+	"github.com/MunishMummadi/mock-trade-algorithm/patterns"
+	"github.com/MunishMummadi/mock-trade-algorithm/regime"
+	"github.com/MunishMummadi/mock-trade-algorithm/rules"
+	"github.com/MunishMummadi/mock-trade-algorithm/shadow"
 	"github.com/MunishMummadi/mock-trade-algorithm/strategies"
+	"github.com/MunishMummadi/mock-trade-algorithm/symbols"
 )
 
 type TradingEngine struct {
-	config       *config.Config
-	db           *database.Database
-	alpacaClient *alpaca.Client
-	strategies   []strategies.Strategy
-	userID       int64
-	running      bool
+	config             *config.Config
+	db                 *database.Database
+	alpacaClient       *alpaca.Client
+	broker             alpaca.OrderPlacer
+	registry           *symbols.Registry
+	corpActions        *corpactions.Processor
+	aggregators        *aggregation.Router
+	adaptive           *aggregation.AdaptiveWeights
+	calibrator         *calibration.Calibrator
+	regimes            *regime.Detector
+	supervisor         *strategies.Supervisor
+	strategies         []strategies.Strategy
+	universeStrategies []strategies.UniverseStrategy
+	shadowStrategies   []strategies.Strategy         // run against the feed but never traded
+	shadowUniverse     []strategies.UniverseStrategy // cross-sectional strategies in shadow mode
+	shadowBook         *shadow.Book
+	streams            map[string]map[string]*strategies.Stream // symbol -> strategy -> running indicators
+	lastBars           map[string]map[string]time.Time          // symbol -> strategy -> day of the last bar handled by OnBar
+	heldSymbols        map[string][]string                      // strategy -> symbols whose positions it manages
+	userID             int64
+	running            bool
 }
 
 func main() {
+	signalReport := flag.Bool("signal-report", false, "print the signal quality report and exit")
+	shadowReport := flag.Bool("shadow-report", false, "print the shadow vs live performance report and exit")
+	weightHistory := flag.Bool("weight-history", false, "print the adaptive strategy weight history, newest first, and exit")
+	historyLimit := flag.Int("history-limit", 50, "number of snapshots printed by -weight-history")
+	reportSince := flag.Duration("report-since", 0, "only include data from this far back in the report (0 for all)")
+	reportStrategy := flag.String("report-strategy", "", "only include signals (or weights) of this strategy in the report")
+	reportSymbol := flag.String("report-symbol", "", "only include signals for this symbol in the report")
+	flag.Parse()
+
 	log.Println("Starting Mock Trade Algorithm...")
 
 	// Load configuration
@@ -43,12 +80,94 @@ func main() {
 	}
 	defer db.Close()
 
+	if *signalReport {
+		filter := database.SignalFilter{Strategy: *reportStrategy, Symbol: *reportSymbol}
+		if *reportSince > 0 {
+			filter.From = time.Now().Add(-*reportSince)
+		}
+		if err := printSignalReport(db, cfg, filter); err != nil {
+			log.Fatalf("Failed to build signal report: %v", err)
+		}
+		return
+	}
+
+	if *shadowReport {
+		var from time.Time
+		if *reportSince > 0 {
+			from = time.Now().Add(-*reportSince)
+		}
+		if err := printShadowReport(db, from); err != nil {
+			log.Fatalf("Failed to build shadow report: %v", err)
+		}
+		return
+	}
+
+	if *weightHistory {
+		if err := printWeightHistory(db, *reportStrategy, *historyLimit); err != nil {
+			log.Fatalf("Failed to load strategy weight history: %v", err)
+		}
+		return
+	}
+
 	// Initialize Alpaca client
 	alpacaClient, err := alpaca.NewClient(cfg)
 	if err != nil {
 		log.Fatalf("Failed to initialize Alpaca client: %v", err)
 	}
 
+	// Load symbol registry and seed the mock market from it
+	registry, err := symbols.Load(db)
+	if err != nil {
+		log.Fatalf("Failed to load symbol registry: %v", err)
+	}
+	alpacaClient.SeedMarket(registry.All())
+
+	// Record announced splits and dividends
+	if cfg.CorporateActionsFile != "" {
+		created, err := corpactions.LoadFile(db, cfg.CorporateActionsFile)
+		if err != nil {
+			log.Fatalf("Failed to load corporate actions: %v", err)
+		}
+		log.Printf("Loaded %d new corporate actions from %s", created, cfg.CorporateActionsFile)
+	}
+
+	// Wrap the mock broker with configurable fault injection
+	faultConfig, err := alpaca.FaultConfigFromConfig(cfg)
+	if err != nil {
+		log.Fatalf("Failed to configure broker fault injection: %v", err)
+	}
+	broker := alpaca.NewFaultInjector(alpacaClient, faultConfig)
+
+	// Performance-adaptive strategy weights, restored from the last run
+	adaptive := aggregation.NewAdaptiveWeights(db, aggregation.AdaptiveConfigFromConfig(cfg))
+	if err := adaptive.Load(); err != nil {
+		log.Fatalf("Failed to load adaptive strategy weights: %v", err)
+	}
+
+	// Signal aggregation policies, per symbol
+	aggregators, err := aggregation.FromConfig(cfg, adaptive)
+	if err != nil {
+		log.Fatalf("Failed to configure signal aggregation: %v", err)
+	}
+
+	// Calibrate signal strengths against historical outcomes
+	calibrator, err := calibration.NewCalibrator(db, calibration.ConfigFromConfig(cfg))
+	if err != nil {
+		log.Fatalf("Failed to configure strength calibration: %v", err)
+	}
+	if err := calibrator.Fit(time.Now()); err != nil {
+		log.Printf("Warning: failed to calibrate signal strengths: %v", err)
+	}
+
+	// Market regimes decide which strategy styles trade each symbol
+	regimes, err := regime.NewDetector(db, regime.ConfigFromConfig(cfg))
+	if err != nil {
+		log.Fatalf("Failed to configure regime detection: %v", err)
+	}
+	if err := regimes.Load(); err != nil {
+		log.Fatalf("Failed to load market regimes: %v", err)
+	}
+
 	// Create or get demo user
 	user, err := getOrCreateDemoUser(db, cfg.InitialBalance)
 	if err != nil {
@@ -58,11 +177,30 @@ func main() {
 	log.Printf("Demo user created/found: %s (ID: %d, Balance: $%.2f)",
 		user.Username, user.ID, user.Balance.InexactFloat64())
 
+	// Strategy calls run isolated so one bad strategy can't stall or crash the engine
+	supervisor := strategies.NewSupervisor(strategies.SupervisorConfig{
+		Timeout:     cfg.StrategyTimeout,
+		MaxFailures: int(cfg.StrategyMaxFailures),
+		Quarantine:  cfg.StrategyQuarantine,
+	})
+
 	// Initialize trading engine
 	engine := &TradingEngine{
 		config:       cfg,
 		db:           db,
 		alpacaClient: alpacaClient,
+		broker:       broker,
+		registry:     registry,
+		corpActions:  corpactions.NewProcessor(db),
+		aggregators:  aggregators,
+		adaptive:     adaptive,
+		calibrator:   calibrator,
+		regimes:      regimes,
+		supervisor:   supervisor,
+		shadowBook:   shadow.NewBook(db, registry, shadow.ConfigFromConfig(cfg)),
+		streams:      make(map[string]map[string]*strategies.Stream),
+		lastBars:     make(map[string]map[string]time.Time),
+		heldSymbols:  make(map[string][]string),
 		userID:       user.ID,
 		running:      true,
 	}
@@ -92,9 +230,44 @@ func main() {
 		log.Fatalf("Trading engine error: %v", err)
 	}
 
+	// Persist strategy state for the next run
+	engine.saveStrategyStates()
+	engine.stopStrategies()
+	engine.calibrator.Wait()
+	engine.supervisor.LogStatus()
+
 	log.Println("Mock Trade Algorithm stopped")
 }
 
+func printSignalReport(db *database.Database, cfg *config.Config, filter database.SignalFilter) error {
+	horizons, err := analytics.ParseHorizons(cfg.SignalReportBars)
+	if err != nil {
+		return fmt.Errorf("invalid SIGNAL_REPORT_BARS: %w", err)
+	}
+
+	report, err := analytics.SignalQuality(db, filter, horizons)
+	if err != nil {
+		return err
+	}
+	return report.Print(os.Stdout)
+}
+
+func printShadowReport(db *database.Database, from time.Time) error {
+	report, err := shadow.Compare(db, from)
+	if err != nil {
+		return err
+	}
+	return report.Print(os.Stdout)
+}
+
+func printWeightHistory(db *database.Database, strategy string, limit int) error {
+	history, err := db.GetStrategyWeightHistory(strategy, limit)
+	if err != nil {
+		return err
+	}
+	return aggregation.PrintWeightHistory(os.Stdout, history)
+}
+
 func getOrCreateDemoUser(db *database.Database, initialBalance float64) (*models.User, error) {
 	// Try to get existing demo user
 	user, err := db.GetUser(1)
@@ -119,27 +292,236 @@ func getOrCreateDemoUser(db *database.Database, initialBalance float64) (*models
 func (e *TradingEngine) initializeStrategies() error {
 	log.Println("Initializing trading strategies...")
 
-	// Initialize Simple Moving Average strategy
-	smaStrategy := strategies.NewSMAStrategy(20, 50) // 20-day and 50-day SMA
-	e.strategies = append(e.strategies, smaStrategy)
+	available := []strategies.Strategy{
+		// Simple Moving Average strategy
+		strategies.NewSMAStrategy(20, 50), // 20-day and 50-day SMA
+
+		// RSI strategy
+		strategies.NewRSIStrategy(14, 30, 70), // 14-period RSI with 30/70 levels
+
+		// Mean Reversion strategy
+		strategies.NewMeanReversionStrategy(20, 2.0), // 20-period with 2 std dev
 
-	// Initialize RSI strategy
-	rsiStrategy := strategies.NewRSIStrategy(14, 30, 70) // 14-period RSI with 30/70 levels
-	e.strategies = append(e.strategies, rsiStrategy)
+		// MACD strategy, 12/26 EMAs with a 9-period signal line by default
+		strategies.NewMACDStrategy(int(e.config.MACDPeriods[0]), int(e.config.MACDPeriods[1]), int(e.config.MACDPeriods[2])),
 
-	// Initialize Mean Reversion strategy
-	meanRevStrategy := strategies.NewMeanReversionStrategy(20, 2.0) // 20-period with 2 std dev
-	e.strategies = append(e.strategies, meanRevStrategy)
+		// EMA crossover strategy, 12-day and 26-day EMA by default
+		strategies.NewEMAStrategy(int(e.config.EMAPeriods[0]), int(e.config.EMAPeriods[1])),
 
-	log.Printf("Initialized %d trading strategies", len(e.strategies))
+		// Donchian breakout strategy, 20-day channel by default
+		strategies.NewDonchianStrategy(int(e.config.DonchianPeriod)),
+
+		// Candlestick pattern strategy, 10-day context, 3% prior move and 1.5x volume by default
+		strategies.NewPatternStrategy(patterns.TolerancesFromConfig(e.config), int(e.config.PatternTrendPeriod),
+			e.config.PatternMinMove, e.config.PatternVolumeRatio),
+	}
+
+	// Strategies loaded at runtime may not reuse the name of a built-in or
+	// of another loaded strategy
+	addLoaded := func(strategy strategies.Strategy) error {
+		for _, existing := range available {
+			if strings.EqualFold(existing.GetName(), strategy.GetName()) {
+				return fmt.Errorf("strategy %q clashes with an existing strategy", strategy.GetName())
+			}
+		}
+		available = append(available, strategy)
+		return nil
+	}
+
+	// Rule strategies written in the rules language
+	if e.config.RuleStrategyDir != "" {
+		loaded, err := rules.LoadDir(e.config.RuleStrategyDir)
+		if err != nil {
+			return fmt.Errorf("failed to load rule strategies: %w", err)
+		}
+		for _, strategy := range loaded {
+			if err := addLoaded(strategy); err != nil {
+				return err
+			}
+		}
+		log.Printf("Loaded %d rule strategies from %s", len(loaded), e.config.RuleStrategyDir)
+	}
+
+	// Strategies running as external processes
+	for _, cfg := range external.ConfigsFromConfig(e.config) {
+		strategy, err := external.Start(cfg)
+		if err != nil {
+			return err
+		}
+		if err := addLoaded(strategy); err != nil {
+			strategy.Close()
+			return err
+		}
+		log.Printf("Started external strategy %s: %s", strategy.GetName(), strings.Join(cfg.Command, " "))
+	}
+
+	// Multi-timeframe wrappers around any strategy loaded so far
+	specs, err := strategies.ParseMultiTimeframeSpecs(e.config.MultiTimeframeStrategies)
+	if err != nil {
+		return fmt.Errorf("invalid MULTI_TIMEFRAME_STRATEGIES: %w", err)
+	}
+	for _, spec := range specs {
+		var inner strategies.Strategy
+		for _, strategy := range available {
+			if strings.EqualFold(strategy.GetName(), spec.Strategy) {
+				inner = strategy
+				break
+			}
+		}
+		if inner == nil {
+			return fmt.Errorf("unknown strategy %q in MULTI_TIMEFRAME_STRATEGIES", spec.Strategy)
+		}
+
+		// Timeframes are resampled from the daily bars the engine fetches
+		if spec.Lower.Length() < strategies.TimeframeDay.Length() {
+			return fmt.Errorf("timeframe %s is finer than the engine's %s bars", spec.Lower, strategies.TimeframeDay)
+		}
+
+		wrapper, err := strategies.NewMultiTimeframeStrategy(inner, spec.Lower, spec.Higher, spec.Filter)
+		if err != nil {
+			return err
+		}
+		if err := addLoaded(wrapper); err != nil {
+			return err
+		}
+		e.regimes.InheritStyle(wrapper.GetName(), inner.GetName())
+	}
+
+	enabled := make(map[string]bool)
+	for _, name := range strings.Split(e.config.EnabledStrategies, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			enabled[strings.ToLower(name)] = true
+		}
+	}
+
+	// Cross-sectional strategies see the whole watchlist at once
+	availableUniverse := []strategies.UniverseStrategy{
+		// Momentum ranking across the watchlist
+		strategies.NewMomentumRankStrategy(20, 2), // 20-day return, top/bottom 2
+
+		// Sector rotation using registry sectors
+		strategies.NewSectorRotationStrategy(20, e.sectorOf), // 20-day sector momentum
+
+		// Pairs trading within sectors
+		strategies.NewPairsStrategy(60, 20, 2.0, 0.5, e.pairable), // 60-day cointegration, 20-day z-score
+	}
+
+	// Shadow strategies run whether or not they are enabled, but never trade
+	shadowed := shadow.ParseStrategies(e.config.ShadowStrategies)
+	for name := range shadowed {
+		if enabled[name] {
+			return fmt.Errorf("strategy %q is in both ENABLED_STRATEGIES and SHADOW_STRATEGIES", name)
+		}
+	}
+
+	matched := make(map[string]bool)
+	isShadow := func(strategy strategies.Describer) bool {
+		name := strings.ToLower(strategy.GetName())
+		if !shadowed[name] {
+			return false
+		}
+		matched[name] = true
+		return true
+	}
+	isEnabled := func(strategy strategies.Describer) bool {
+		name := strings.ToLower(strategy.GetName())
+		if len(enabled) > 0 && !enabled[name] {
+			return false
+		}
+		matched[name] = true
+		return true
+	}
+
+	var shadowNames []string
+	for _, strategy := range available {
+		switch {
+		case isShadow(strategy):
+			e.shadowStrategies = append(e.shadowStrategies, strategy)
+			shadowNames = append(shadowNames, strategy.GetName())
+		case isEnabled(strategy):
+			e.strategies = append(e.strategies, strategy)
+		default:
+			if closer, ok := strategy.(strategies.Closer); ok {
+				closer.Close()
+			}
+		}
+	}
+	for _, strategy := range availableUniverse {
+		switch {
+		case isShadow(strategy):
+			e.shadowUniverse = append(e.shadowUniverse, strategy)
+			shadowNames = append(shadowNames, strategy.GetName())
+		case isEnabled(strategy):
+			e.universeStrategies = append(e.universeStrategies, strategy)
+		}
+	}
+
+	for name := range enabled {
+		if !matched[name] {
+			return fmt.Errorf("unknown strategy %q in ENABLED_STRATEGIES", name)
+		}
+	}
+	for name := range shadowed {
+		if !matched[name] {
+			return fmt.Errorf("unknown strategy %q in SHADOW_STRATEGIES", name)
+		}
+	}
+
+	// Weights and overrides must name strategies and symbols that exist
+	var names []string
+	for _, strategy := range available {
+		names = append(names, strategy.GetName())
+	}
+	for _, strategy := range availableUniverse {
+		names = append(names, strategy.GetName())
+	}
+	if err := aggregation.CheckKeys(e.config, names, e.registry.Watchlist()); err != nil {
+		return err
+	}
+
+	if err := e.shadowBook.Load(shadowNames); err != nil {
+		return fmt.Errorf("failed to load shadow portfolios: %w", err)
+	}
+
+	if err := e.startStrategies(); err != nil {
+		return err
+	}
+
+	log.Printf("Initialized %d trading strategies", len(e.strategies)+len(e.universeStrategies))
+	if len(shadowNames) > 0 {
+		log.Printf("Running %d strategies in shadow mode: %s", len(shadowNames), strings.Join(shadowNames, ", "))
+	}
 	return nil
 }
 
+// sectorOf looks up a symbol's sector in the registry
+func (e *TradingEngine) sectorOf(symbol string) string {
+	asset, err := e.registry.Lookup(symbol)
+	if err != nil {
+		return "Unknown"
+	}
+	return asset.Sector
+}
+
+// pairable reports whether two symbols can be traded as a pair: both must be
+// shortable, since either leg may be the short one, and in the same sector
+func (e *TradingEngine) pairable(a, b string) bool {
+	assetA, err := e.registry.Lookup(a)
+	if err != nil {
+		return false
+	}
+	assetB, err := e.registry.Lookup(b)
+	if err != nil {
+		return false
+	}
+	return assetA.Shortable && assetB.Shortable && assetA.Sector == assetB.Sector
+}
+
 func (e *TradingEngine) run(ctx context.Context) error {
 	log.Println("Starting trading engine main loop...")
 
-	// Define watchlist of symbols to trade
-	watchlist := []string{"AAPL", "GOOGL", "MSFT", "TSLA", "AMZN", "NVDA", "META", "NFLX"}
+	// Trade every active symbol in the registry; crypto pairs trade around the clock
+	watchlist := e.registry.Watchlist()
 
 	ticker := time.NewTicker(e.config.RefreshInterval)
 	defer ticker.Stop()
@@ -157,6 +539,7 @@ func (e *TradingEngine) run(ctx context.Context) error {
 				log.Printf("Error in trading cycle: %v", err)
 				continue
 			}
+			e.saveStrategyStates()
 		}
 	}
 }
@@ -164,29 +547,46 @@ func (e *TradingEngine) run(ctx context.Context) error {
 func (e *TradingEngine) processTradingCycle(ctx context.Context, symbols []string) error {
 	log.Println("Processing trading cycle...")
 
-	// Check if market is open
-	isOpen, err := e.alpacaClient.IsMarketOpen(ctx)
+	// Only trade symbols whose asset class session is open
+	symbols, err := e.openSymbols(ctx, symbols)
 	if err != nil {
 		return fmt.Errorf("failed to check market status: %w", err)
 	}
 
-	if !isOpen {
-		log.Println("Market is closed, skipping trading cycle")
+	if len(symbols) == 0 {
+		log.Println("All markets are closed, skipping trading cycle")
 		return nil
 	}
 
+	// Apply splits and dividends that have come due
+	splits, err := e.corpActions.Process(e.userID, time.Now())
+	if err != nil {
+		log.Printf("Warning: failed to process corporate actions: %v", err)
+	}
+	for _, split := range splits {
+		e.alpacaClient.ApplySplit(split.Symbol, split.Ratio)
+		// History is re-adjusted, so running indicators must be rebuilt
+		delete(e.streams, split.Symbol)
+	}
+
 	// Get current prices for all symbols
 	prices, err := e.alpacaClient.GetMultiplePrices(ctx, symbols)
 	if err != nil {
 		return fmt.Errorf("failed to get current prices: %w", err)
 	}
 
+	// Record price snapshots; signal analytics measures forward returns against them
+	e.recordMarketData(prices, time.Now())
+
 	// Get current user and portfolio
 	user, err := e.db.GetUser(e.userID)
 	if err != nil {
 		return fmt.Errorf("failed to get user: %w", err)
 	}
 
+	// Settle orders whose acknowledgement never arrived
+	e.reconcilePendingTrades(ctx, user)
+
 	portfolio, err := e.db.GetPortfolioByUser(e.userID)
 	if err != nil {
 		return fmt.Errorf("failed to get portfolio: %w", err)
@@ -197,79 +597,341 @@ func (e *TradingEngine) processTradingCycle(ctx context.Context, symbols []strin
 		log.Printf("Warning: failed to update portfolio values: %v", err)
 	}
 
-	// Process each symbol with all strategies
+	// Record live and shadow equity so shadow strategies can be compared
+	if e.shadowing() {
+		e.recordEquity(user, portfolio, prices, time.Now())
+	}
+
+	// Refit strength calibration in the background as new outcomes come in
+	e.calibrator.MaybeRefit(time.Now())
+
+	// Score signals whose horizon has passed and update strategy weights
+	if err := e.adaptive.Resolve(prices, time.Now()); err != nil {
+		log.Printf("Warning: failed to update strategy weights: %v", err)
+	}
+
+	// Load history for every symbol before analysis so cross-sectional
+	// strategies see the whole universe
+	universe := make(map[string]*strategies.Features)
 	for _, symbol := range symbols {
-		price, exists := prices[symbol]
-		if !exists {
+		if _, exists := prices[symbol]; !exists {
 			log.Printf("Warning: price not available for %s", symbol)
 			continue
 		}
 
-		if err := e.processSymbol(ctx, symbol, price, user, portfolio); err != nil {
+		features, err := e.loadFeatures(ctx, symbol)
+		if err != nil {
 			log.Printf("Error processing symbol %s: %v", symbol, err)
+			continue
+		}
+		if features != nil {
+			universe[symbol] = features
 		}
 	}
 
+	// Run cross-sectional strategies once over the whole universe
+	universeSignals, multiLeg := e.analyzeUniverse(ctx, e.universeStrategies, universe, prices)
+	e.updateHeldSymbols(ctx)
+
+	// Process each symbol with all strategies
+	for _, symbol := range strategies.Universe(universe) {
+		if err := e.processSymbol(ctx, universe[symbol], prices[symbol], user, portfolio, universeSignals[symbol]); err != nil {
+			log.Printf("Error processing symbol %s: %v", symbol, err)
+		}
+	}
+
+	// Multi-leg signals bypass voting and are executed as a unit. They keep
+	// their raw strength: calibration scores directional returns, which say
+	// nothing about a spread
+	for _, signal := range multiLeg {
+		signal.Decision = models.SignalDecisionTraded
+		if err := e.executeLegs(ctx, signal, user, prices); err != nil {
+			log.Printf("Failed to execute %s legs for %s: %v", signal.Strategy, signal.Symbol, err)
+			signal.Decision = models.SignalDecisionFailed
+		}
+		if err := e.db.CreateTradingSignal(signal); err != nil {
+			log.Printf("Warning: %v", err)
+		}
+	}
+
+	// Run shadow strategies into their virtual portfolios
+	if e.shadowing() {
+		e.processShadow(ctx, universe, prices)
+	}
+
+	// Report quarantined and failing strategies
+	e.supervisor.LogStatus()
+
 	// Print portfolio summary
 	e.printPortfolioSummary(user, portfolio, prices)
+	if e.shadowing() {
+		e.shadowBook.LogSummary(prices)
+	}
 
 	return nil
 }
 
-func (e *TradingEngine) processSymbol(ctx context.Context, symbol string, price decimal.Decimal,
-	user *models.User, portfolio []*models.Portfolio) error {
+// recordMarketData stores one snapshot per symbol for this cycle. The mock
+// only quotes a last price, so the snapshot's OHLC are all that price.
+func (e *TradingEngine) recordMarketData(prices map[string]decimal.Decimal, at time.Time) {
+	for symbol, price := range prices {
+		err := e.db.UpsertMarketData(&models.MarketData{
+			Symbol:    symbol,
+			Price:     price,
+			High:      price,
+			Low:       price,
+			Open:      price,
+			Close:     price,
+			Timestamp: at,
+		})
+		if err != nil {
+			log.Printf("Warning: %v", err)
+			continue
+		}
+	}
+}
+
+// openSymbols filters the watchlist down to symbols whose market is open,
+// checking each asset class calendar once per cycle
+func (e *TradingEngine) openSymbols(ctx context.Context, symbols []string) ([]string, error) {
+	sessions := make(map[models.AssetClass]bool)
+	open := make([]string, 0, len(symbols))
+
+	for _, symbol := range symbols {
+		asset, err := e.registry.Lookup(symbol)
+		if err != nil {
+			log.Printf("Warning: %v", err)
+			continue
+		}
+
+		isOpen, checked := sessions[asset.Class]
+		if !checked {
+			isOpen, err = e.alpacaClient.IsMarketOpenFor(ctx, asset.Class)
+			if err != nil {
+				return nil, err
+			}
+			sessions[asset.Class] = isOpen
+			if !isOpen {
+				log.Printf("Market is closed for %s, skipping those symbols", asset.Class)
+			}
+		}
 
+		if isOpen {
+			open = append(open, symbol)
+		}
+	}
+
+	return open, nil
+}
+
+// loadFeatures fetches and adjusts a symbol's history and wraps it in a
+// feature frame, returning nil when there is not enough data to analyze
+func (e *TradingEngine) loadFeatures(ctx context.Context, symbol string) (*strategies.Features, error) {
 	// Get historical data for analysis
 	bars, err := e.alpacaClient.GetBars(ctx, symbol,
-		"1Day", time.Now().AddDate(0, 0, -100), time.Now())
+		string(strategies.TimeframeDay), time.Now().AddDate(0, 0, -100), time.Now())
 	if err != nil {
-		return fmt.Errorf("failed to get historical data for %s: %w", symbol, err)
+		return nil, fmt.Errorf("failed to get historical data for %s: %w", symbol, err)
 	}
 
 	if len(bars) < 50 { // Need enough data for analysis
-		return nil
+		return nil, nil
 	}
 
-	// Run all strategies for this symbol
-	signals := make([]*models.TradingSignal, 0)
+	// Analyze split/dividend adjusted history so actions don't look like price moves
+	actions, err := e.db.GetCorporateActions(symbol)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get corporate actions for %s: %w", symbol, err)
+	}
+	bars = corpactions.AdjustBars(bars, actions, corpactions.Adjustment(e.config.BarAdjustment))
 
-	for _, strategy := range e.strategies {
-		signal := strategy.Analyze(symbol, bars, price)
-		if signal != nil {
-			signals = append(signals, signal)
+	// One feature frame per symbol per cycle is shared by all strategies
+	return strategies.NewFeatures(symbol, bars), nil
+}
+
+// analyzeUniverse runs the cross-sectional strategies, grouping single-leg
+// signals by symbol and returning multi-leg signals separately
+func (e *TradingEngine) analyzeUniverse(ctx context.Context, universeStrategies []strategies.UniverseStrategy,
+	universe map[string]*strategies.Features, prices map[string]decimal.Decimal) (map[string][]*models.TradingSignal, []*models.TradingSignal) {
+
+	bySymbol := make(map[string][]*models.TradingSignal)
+	var multiLeg []*models.TradingSignal
+	for _, strategy := range universeStrategies {
+		var signals []*models.TradingSignal
+		err := e.supervisor.Run(ctx, strategy.GetName(), func() error {
+			signals = strategy.AnalyzeUniverse(universe, prices)
+			for _, signal := range signals {
+				if err := strategies.ValidateSignal(signal, ""); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			continue
+		}
+
+		for _, signal := range signals {
+			if len(signal.Legs) > 0 {
+				multiLeg = append(multiLeg, signal)
+				continue
+			}
+			if _, exists := universe[signal.Symbol]; !exists {
+				continue
+			}
+			bySymbol[signal.Symbol] = append(bySymbol[signal.Symbol], signal)
+		}
+	}
+	return bySymbol, multiLeg
+}
+
+// updateHeldSymbols records the symbols each live universe strategy manages
+// positions in. A strategy that can't be asked keeps its last answer.
+func (e *TradingEngine) updateHeldSymbols(ctx context.Context) {
+	for _, strategy := range e.universeStrategies {
+		holder, ok := strategy.(strategies.PositionHolder)
+		if !ok {
+			continue
+		}
+
+		var symbols []string
+		err := e.supervisor.Run(ctx, strategy.GetName(), func() error {
+			symbols = holder.HeldSymbols()
+			return nil
+		})
+		if err == nil {
+			e.heldSymbols[strategy.GetName()] = symbols
 		}
 	}
+}
+
+// heldBy returns the strategy that manages the position in symbol, if any
+func (e *TradingEngine) heldBy(symbol string) string {
+	for strategy, symbols := range e.heldSymbols {
+		for _, held := range symbols {
+			if held == symbol {
+				return strategy
+			}
+		}
+	}
+	return ""
+}
+
+func (e *TradingEngine) processSymbol(ctx context.Context, features *strategies.Features, price decimal.Decimal,
+	user *models.User, portfolio []*models.Portfolio, universeSignals []*models.TradingSignal) error {
+
+	symbol := features.Symbol
+	bars := features.Bars
+
+	// Deliver new bars to strategies that track them
+	e.dispatchBars(ctx, symbol, bars)
+
+	// Classify the market before its signals are gated by it
+	e.regimes.Update(features)
+
+	// Run all strategies for this symbol, starting from the cross-sectional signals
+	signals := append([]*models.TradingSignal{}, universeSignals...)
+	signals = append(signals, e.analyzeSymbol(ctx, e.strategies, features, price)...)
+
+	// Size and vote on calibrated probabilities rather than raw strengths
+	e.calibrator.CalibrateAll(signals)
+
+	// Drop or reweight signals whose style doesn't suit the symbol's regime
+	signals, disabled := e.regimes.Apply(symbol, signals)
+	e.recordDisabled(disabled)
+
+	// Track every signal's outcome for adaptive weighting
+	e.adaptive.Observe(signals)
 
 	// Process signals and make trading decisions
 	if len(signals) > 0 {
 		decision := e.makeTradeDecision(signals, symbol, price, user, portfolio)
+		var execErr error
 		if decision != nil {
-			if err := e.executeTrade(ctx, decision, user); err != nil {
-				log.Printf("Failed to execute trade for %s: %v", symbol, err)
+			if execErr = e.executeTrade(ctx, decision, user); execErr != nil {
+				log.Printf("Failed to execute trade for %s: %v", symbol, execErr)
 			}
+			e.adaptive.ObserveFill(decision)
+			e.notifyOrderUpdate(decision, signals)
 		}
+		e.recordSignals(signals, decision, execErr)
 	}
 
 	return nil
 }
 
-func (e *TradingEngine) makeTradeDecision(signals []*models.TradingSignal, symbol string,
-	currentPrice decimal.Decimal, user *models.User, portfolio []*models.Portfolio) *models.Trade {
+// analyzeSymbol runs the per-symbol strategies over a symbol's features
+func (e *TradingEngine) analyzeSymbol(ctx context.Context, symbolStrategies []strategies.Strategy,
+	features *strategies.Features, price decimal.Decimal) []*models.TradingSignal {
 
-	// Count buy and sell signals
-	buySignals := 0
-	sellSignals := 0
-	totalStrength := 0.0
+	symbol := features.Symbol
+	bars := features.Bars
 
-	for _, signal := range signals {
-		if signal.Signal == "BUY" {
-			buySignals++
-		} else if signal.Signal == "SELL" {
-			sellSignals++
+	var signals []*models.TradingSignal
+	for _, strategy := range symbolStrategies {
+		// Streams are looked up here since a call that times out keeps
+		// running and must not race on the engine's maps
+		var stream *strategies.Stream
+		if s, ok := strategy.(strategies.IncrementalStrategy); ok {
+			stream = e.stream(symbol, s)
 		}
-		totalStrength += signal.Strength
+
+		var signal *models.TradingSignal
+		err := e.supervisor.Run(ctx, strategy.GetName(), func() error {
+			// Incremental strategies fold in only the new bars; their
+			// streams rebuild when the history was regenerated, as the mock
+			// market does on every call. Others prefer the shared frame,
+			// then raw bars; checked strategies report why they produced
+			// no signal.
+			switch s := strategy.(type) {
+			case strategies.IncrementalStrategy:
+				stream.Advance(bars)
+				signal = stream.Signal(symbol, price)
+			case strategies.FeatureStrategy:
+				signal = s.AnalyzeFeatures(features, price)
+			case strategies.CheckedStrategy:
+				var err error
+				if signal, err = s.AnalyzeChecked(symbol, bars, price); err != nil {
+					return err
+				}
+			default:
+				signal = strategy.Analyze(symbol, bars, price)
+			}
+			if signal == nil {
+				return nil
+			}
+			return strategies.ValidateSignal(signal, symbol)
+		})
+		if err == nil && signal != nil {
+			signals = append(signals, signal)
+		}
+	}
+	return signals
+}
+
+// stream returns the running indicator state for a symbol and strategy,
+// creating it on first use; the first Advance warms it up on full history
+func (e *TradingEngine) stream(symbol string, strategy strategies.IncrementalStrategy) *strategies.Stream {
+	bySymbol, exists := e.streams[symbol]
+	if !exists {
+		bySymbol = make(map[string]*strategies.Stream)
+		e.streams[symbol] = bySymbol
 	}
 
+	stream, exists := bySymbol[strategy.GetName()]
+	if !exists {
+		stream = strategies.NewStream(strategy, strategies.TimeframeDay)
+		bySymbol[strategy.GetName()] = stream
+	}
+	return stream
+}
+
+func (e *TradingEngine) makeTradeDecision(signals []*models.TradingSignal, symbol string,
+	currentPrice decimal.Decimal, user *models.User, portfolio []*models.Portfolio) *models.Trade {
+
+	// Combine the signals with the symbol's aggregation policy
+	combined := e.aggregators.For(symbol).Aggregate(signals)
+
 	// Get current position for this symbol
 	var currentPosition *models.Portfolio
 	for _, pos := range portfolio {
@@ -279,37 +941,139 @@ func (e *TradingEngine) makeTradeDecision(signals []*models.TradingSignal, symbo
 		}
 	}
 
+	asset, err := e.registry.Lookup(symbol)
+	if err != nil {
+		log.Printf("Warning: %v", err)
+		return nil
+	}
+
 	// Calculate position size based on risk management
 	maxPositionValue := decimal.NewFromFloat(e.config.MaxPositionSize)
 	riskAmount := user.Balance.Mul(decimal.NewFromFloat(e.config.RiskPercentage))
 
+	// Cap the position by the room left in the symbol's sector
+	sectorRoom := e.sectorRoom(asset.Sector, user, portfolio)
+	if sectorRoom.LessThan(maxPositionValue) {
+		maxPositionValue = sectorRoom
+	}
+
 	// Decision logic
-	if buySignals > sellSignals && buySignals >= 2 {
-		// Strong buy signal
+	var trade *models.Trade
+	switch combined.Signal {
+	case "BUY":
 		if currentPosition == nil || currentPosition.Quantity.IsZero() {
 			// Calculate quantity to buy
-			positionValue := decimal.Min(maxPositionValue, riskAmount.Mul(decimal.NewFromFloat(totalStrength)))
-			quantity := positionValue.Div(currentPrice).Truncate(0)
+			positionValue := decimal.Min(maxPositionValue, riskAmount.Mul(decimal.NewFromFloat(combined.Multiplier)))
+			if combined.TargetWeight > 0 {
+				// Cross-sectional strategies may ask for a specific allocation
+				positionValue = decimal.Min(maxPositionValue, e.totalValue(user, portfolio).Mul(decimal.NewFromFloat(combined.TargetWeight)))
+			}
+			quantity := asset.RoundQuantity(positionValue.Div(currentPrice))
 
 			if quantity.GreaterThan(decimal.Zero) && user.CanAfford(quantity.Mul(currentPrice)) {
-				return models.NewTrade(user.ID, symbol, models.OrderSideBuy,
+				trade = models.NewTrade(user.ID, symbol, models.OrderSideBuy,
 					models.TradeTypeMarket, quantity, currentPrice, "multi_strategy")
 			}
 		}
-	} else if sellSignals > buySignals && sellSignals >= 2 {
-		// Strong sell signal
+	case "SELL":
+		if owner := e.heldBy(symbol); owner != "" {
+			// Closing one leg of a pair would leave the other unhedged
+			log.Printf("Not selling %s on per-symbol signals: its position is managed by %s", symbol, owner)
+			break
+		}
 		if currentPosition != nil && currentPosition.Quantity.GreaterThan(decimal.Zero) {
 			// Sell the position
-			return models.NewTrade(user.ID, symbol, models.OrderSideSell,
+			trade = models.NewTrade(user.ID, symbol, models.OrderSideSell,
 				models.TradeTypeMarket, currentPosition.Quantity, currentPrice, "multi_strategy")
 		}
 	}
 
-	return nil
+	if trade != nil {
+		trade.Notes = tradeNotes(combined.Notes, signals, signalSide(trade.Side))
+	}
+	return trade
+}
+
+// tradeNotes combines the aggregation summary with the reason of every
+// signal that agreed with the trade
+func tradeNotes(summary string, signals []*models.TradingSignal, side string) string {
+	notes := []string{summary}
+	for _, signal := range signals {
+		if signal.Signal == side && signal.Reason != "" {
+			notes = append(notes, fmt.Sprintf("%s: %s", signal.Strategy, signal.Reason))
+		}
+	}
+	return strings.Join(notes, "; ")
+}
+
+// recordSignals persists every signal of a symbol along with what the
+// engine did about it, so decisions can be audited later
+func (e *TradingEngine) recordSignals(signals []*models.TradingSignal, trade *models.Trade, execErr error) {
+	for _, signal := range signals {
+		switch {
+		case trade == nil:
+			signal.Decision = models.SignalDecisionNoTrade
+		case signal.Signal != signalSide(trade.Side):
+			signal.Decision = models.SignalDecisionOutvoted
+		case execErr != nil:
+			signal.Decision = models.SignalDecisionFailed
+			signal.TradeID = trade.ID
+		default:
+			signal.Decision = models.SignalDecisionTraded
+			signal.TradeID = trade.ID
+		}
+
+		if err := e.db.CreateTradingSignal(signal); err != nil {
+			log.Printf("Warning: %v", err)
+		}
+	}
+}
+
+// recordDisabled persists the signals a symbol's regime switched off
+func (e *TradingEngine) recordDisabled(signals []*models.TradingSignal) {
+	for _, signal := range signals {
+		signal.Decision = models.SignalDecisionRegimeDisabled
+		if err := e.db.CreateTradingSignal(signal); err != nil {
+			log.Printf("Warning: %v", err)
+		}
+	}
+}
+
+// signalSide maps an order side to the signal that asks for it
+func signalSide(side models.OrderSide) string {
+	if side == models.OrderSideSell {
+		return "SELL"
+	}
+	return "BUY"
+}
+
+// sectorRoom returns how much more value may be allocated to a sector before
+// it exceeds MaxSectorExposure of the total portfolio value
+func (e *TradingEngine) sectorRoom(sector string, user *models.User, portfolio []*models.Portfolio) decimal.Decimal {
+	limit := e.totalValue(user, portfolio).Mul(decimal.NewFromFloat(e.config.MaxSectorExposure))
+	room := limit.Sub(e.registry.SectorExposure(portfolio)[sector])
+	if room.IsNegative() {
+		return decimal.Zero
+	}
+	return room
+}
+
+// totalValue returns cash plus the current value of all positions
+func (e *TradingEngine) totalValue(user *models.User, portfolio []*models.Portfolio) decimal.Decimal {
+	totalValue := user.Balance
+	for _, position := range portfolio {
+		totalValue = totalValue.Add(position.CurrentValue)
+	}
+	return totalValue
 }
 
 func (e *TradingEngine) executeTrade(ctx context.Context, trade *models.Trade, user *models.User) error {
-	log.Printf("Executing %s trade: %s %s shares at $%.2f",
+	// Validate against symbol metadata and round to lot and tick sizes
+	if err := e.registry.ValidateOrder(trade, e.positionQuantity(user.ID, trade.Symbol)); err != nil {
+		return fmt.Errorf("order validation failed: %w", err)
+	}
+
+	log.Printf("Executing %s trade: %s %s units at $%.2f",
 		trade.Side, trade.Quantity.String(), trade.Symbol, trade.Price.InexactFloat64())
 
 	// Save trade to database
@@ -317,11 +1081,25 @@ func (e *TradingEngine) executeTrade(ctx context.Context, trade *models.Trade, u
 		return fmt.Errorf("failed to save trade: %w", err)
 	}
 
-	// Execute using mock trading (for safety)
-	if err := e.alpacaClient.MockPlaceOrder(trade); err != nil {
-		trade.Status = models.TradeStatusRejected
-		e.db.UpdateTrade(trade)
-		return fmt.Errorf("failed to execute trade: %w", err)
+	// Execute using mock trading (for safety), waiting a bounded time for
+	// the acknowledgement
+	orderCtx, cancel := context.WithTimeout(ctx, e.config.OrderAckTimeout)
+	defer cancel()
+	if err := e.broker.MockPlaceOrder(orderCtx, trade); err != nil {
+		switch {
+		case errors.Is(err, alpaca.ErrDuplicateFill):
+			// The order filled; make sure the fill is only applied once
+			log.Printf("Warning: duplicate fill reported for %s, applying it once", trade.Symbol)
+		case errors.Is(err, alpaca.ErrBrokerTimeout):
+			// The order state is unknown, so leave it pending rather than
+			// guess; reconcilePendingTrades resolves it later
+			e.db.UpdateTrade(trade)
+			return fmt.Errorf("failed to execute trade: %w", err)
+		default:
+			trade.Status = models.TradeStatusRejected
+			e.db.UpdateTrade(trade)
+			return fmt.Errorf("failed to execute trade: %w", err)
+		}
 	}
 
 	// Update trade status
@@ -338,6 +1116,166 @@ func (e *TradingEngine) executeTrade(ctx context.Context, trade *models.Trade, u
 	return nil
 }
 
+// executeLegs places every leg of a multi-leg signal. All legs are validated
+// before any is placed, and if a leg fails the legs already filled are
+// reversed so the position is never left half-open.
+func (e *TradingEngine) executeLegs(ctx context.Context, signal *models.TradingSignal,
+	user *models.User, prices map[string]decimal.Decimal) error {
+
+	// Entry legs split the gross notional by weight
+	gross := decimal.NewFromFloat(e.config.MaxPositionSize).Mul(decimal.NewFromFloat(signal.Strength))
+
+	trades := make([]*models.Trade, 0, len(signal.Legs))
+	buyCost := decimal.Zero
+	for i, leg := range signal.Legs {
+		price, exists := prices[leg.Symbol]
+		if !exists {
+			return fmt.Errorf("price not available for leg %s", leg.Symbol)
+		}
+		asset, err := e.registry.Lookup(leg.Symbol)
+		if err != nil {
+			return err
+		}
+
+		quantity := leg.Quantity
+		if !quantity.IsPositive() {
+			quantity = asset.RoundQuantity(gross.Mul(decimal.NewFromFloat(leg.Weight)).Div(price))
+		}
+
+		trade := models.NewTrade(user.ID, leg.Symbol, leg.Side, models.TradeTypeMarket, quantity, price, signal.Strategy)
+		trade.Notes = fmt.Sprintf("leg %d of %d: %s", i+1, len(signal.Legs), signal.Reason)
+		if err := e.registry.ValidateOrder(trade, e.positionQuantity(user.ID, leg.Symbol)); err != nil {
+			return fmt.Errorf("leg %s failed validation: %w", leg.Symbol, err)
+		}
+		if trade.Side == models.OrderSideBuy {
+			buyCost = buyCost.Add(trade.Quantity.Mul(trade.Price))
+		}
+		trades = append(trades, trade)
+	}
+
+	if !user.CanAfford(buyCost) {
+		return fmt.Errorf("insufficient balance for legs costing $%.2f", buyCost.InexactFloat64())
+	}
+
+	strategy := e.strategyByName(signal.Strategy)
+	for i, trade := range trades {
+		err := e.executeTrade(ctx, trade, user)
+		if err == nil && trade.Status != models.TradeStatusFilled {
+			err = fmt.Errorf("order ended %s", trade.Status)
+		}
+		if i == 0 {
+			// The signal is linked to its first leg
+			signal.TradeID = trade.ID
+		}
+		if err != nil {
+			if trade.Status == models.TradeStatusPending {
+				// The leg may still fill; reconcilePendingTrades unwinds it
+				// if it does, so the aborted signal stays flat
+				trade.Notes = abortedLegNote + trade.Notes
+				if err := e.db.UpdateTrade(trade); err != nil {
+					log.Printf("Warning: failed to mark %s leg as aborted: %v", trade.Symbol, err)
+				}
+			}
+			e.unwindLegs(ctx, trades[:i], user, strategy)
+			return fmt.Errorf("leg %s failed: %w", trade.Symbol, err)
+		}
+		e.notifyStrategy(strategy, trade)
+	}
+
+	return nil
+}
+
+// abortedLegNote prefixes the notes of a pending leg whose signal was
+// aborted and unwound
+const abortedLegNote = "aborted "
+
+// reconcilePendingTrades asks the broker how each pending trade ended and
+// applies the outcome. Fills update the balance and portfolio as if they had
+// been acknowledged; orders the broker never received are expired.
+func (e *TradingEngine) reconcilePendingTrades(ctx context.Context, user *models.User) {
+	trades, err := e.db.GetTradesByStatus(user.ID, models.TradeStatusPending)
+	if err != nil {
+		log.Printf("Warning: failed to load pending trades: %v", err)
+		return
+	}
+
+	for _, trade := range trades {
+		order, err := e.broker.GetOrder(ctx, trade.AlpacaOrderID)
+		switch {
+		case errors.Is(err, alpaca.ErrOrderNotFound):
+			trade.Status = models.TradeStatusExpired
+			trade.UpdatedAt = time.Now()
+		case err != nil:
+			log.Printf("Warning: failed to reconcile trade %d: %v", trade.ID, err)
+			continue
+		case order.Status == string(models.TradeStatusFilled):
+			trade.MarkFilled(order.Price, decimal.Zero)
+			if order.FilledAt != nil {
+				trade.FilledAt = order.FilledAt
+			}
+		case order.Status == string(models.TradeStatusPending):
+			// Still working at the broker
+			continue
+		default:
+			trade.Status = models.TradeStatus(order.Status)
+			trade.UpdatedAt = time.Now()
+		}
+
+		if err := e.db.UpdateTrade(trade); err != nil {
+			log.Printf("Warning: %v", err)
+			continue
+		}
+		if err := e.updateUserBalanceAndPortfolio(trade, user); err != nil {
+			log.Printf("Warning: failed to apply reconciled trade %d: %v", trade.ID, err)
+			continue
+		}
+		log.Printf("Reconciled pending %s trade %d for %s: %s", trade.Side, trade.ID, trade.Symbol, trade.Status)
+
+		// The rest of an aborted signal was already unwound and its strategy
+		// never saw this leg, so reverse it rather than report it
+		if strings.HasPrefix(trade.Notes, abortedLegNote) {
+			if trade.Status == models.TradeStatusFilled {
+				e.unwindLegs(ctx, []*models.Trade{trade}, user, nil)
+			}
+			continue
+		}
+		e.notifyStrategy(e.strategyByName(trade.Strategy), trade)
+	}
+}
+
+// unwindLegs reverses filled legs after a later leg of the same signal
+// failed. The strategy, if any, is told about the reversing fills.
+func (e *TradingEngine) unwindLegs(ctx context.Context, filled []*models.Trade, user *models.User, strategy strategies.Describer) {
+	for _, leg := range filled {
+		side := models.OrderSideSell
+		if leg.Side == models.OrderSideSell {
+			side = models.OrderSideBuy
+		}
+
+		trade := models.NewTrade(user.ID, leg.Symbol, side, models.TradeTypeMarket, leg.Quantity, leg.FillPrice, leg.Strategy)
+		trade.Notes = fmt.Sprintf("unwind of trade %d", leg.ID)
+		if err := e.executeTrade(ctx, trade, user); err != nil {
+			log.Printf("Warning: failed to unwind %s leg, position left open: %v", leg.Symbol, err)
+			continue
+		}
+		e.notifyStrategy(strategy, trade)
+	}
+}
+
+// positionQuantity returns the signed quantity held in a symbol
+func (e *TradingEngine) positionQuantity(userID int64, symbol string) decimal.Decimal {
+	portfolio, err := e.db.GetPortfolioByUser(userID)
+	if err != nil {
+		return decimal.Zero
+	}
+	for _, position := range portfolio {
+		if position.Symbol == symbol {
+			return position.Quantity
+		}
+	}
+	return decimal.Zero
+}
+
 func (e *TradingEngine) updateUserBalanceAndPortfolio(trade *models.Trade, user *models.User) error {
 	if trade.Status != models.TradeStatusFilled {
 		return nil
@@ -419,7 +1357,7 @@ func (e *TradingEngine) printPortfolioSummary(user *models.User, portfolio []*mo
 	for _, position := range portfolio {
 		if !position.Quantity.IsZero() {
 			currentPrice := prices[position.Symbol]
-			log.Printf("%s: %s shares @ $%.2f (avg: $%.2f) = $%.2f (P&L: $%.2f)",
+			log.Printf("%s: %s units @ $%.2f (avg: $%.2f) = $%.2f (P&L: $%.2f)",
 				position.Symbol,
 				position.Quantity.String(),
 				currentPrice.InexactFloat64(),
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/MunishMummadi/mock-trade-algorithm/alpaca"
+	"github.com/MunishMummadi/mock-trade-algorithm/config"
+	"github.com/MunishMummadi/mock-trade-algorithm/database"
+	"github.com/MunishMummadi/mock-trade-algorithm/models"
+	"github.com/MunishMummadi/mock-trade-algorithm/symbols"
+)
+
+// newTestEngine returns an engine trading through a seeded fault injector
+// against a fresh database, with a funded user
+func newTestEngine(t *testing.T, faults alpaca.FaultConfig) (*TradingEngine, *models.User) {
+	t.Helper()
+
+	db, err := database.New(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	registry, err := symbols.Load(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &config.Config{OrderAckTimeout: 50 * time.Millisecond}
+	client, err := alpaca.NewClient(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.SeedMarket(registry.All())
+
+	user := models.NewUser("test", "test@example.com", decimal.NewFromInt(100000))
+	if err := db.CreateUser(user); err != nil {
+		t.Fatal(err)
+	}
+
+	if faults.Seed == 0 {
+		faults.Seed = 42
+	}
+	engine := &TradingEngine{
+		config:       cfg,
+		db:           db,
+		alpacaClient: client,
+		broker:       alpaca.NewFaultInjector(client, faults),
+		registry:     registry,
+		userID:       user.ID,
+	}
+	return engine, user
+}
+
+func testBuy(user *models.User) *models.Trade {
+	return models.NewTrade(user.ID, "AAPL", models.OrderSideBuy, models.TradeTypeMarket,
+		decimal.NewFromInt(10), decimal.NewFromInt(190), "Test")
+}
+
+// assertBooked checks the stored status of the user's only trade, the cash
+// balance and the AAPL position
+func assertBooked(t *testing.T, e *TradingEngine, user *models.User, status models.TradeStatus, position int64) {
+	t.Helper()
+
+	trades, err := e.db.GetTradesByUser(user.ID, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(trades) != 1 || trades[0].Status != status {
+		t.Fatalf("stored trades %+v, want one %s trade", trades, status)
+	}
+
+	stored, err := e.db.GetUser(user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spent := decimal.NewFromInt(100000).Sub(stored.Balance)
+	if filled := status == models.TradeStatusFilled; filled != spent.IsPositive() {
+		t.Fatalf("balance %s after a %s trade", stored.Balance, status)
+	}
+	if filled := status == models.TradeStatusFilled; filled && !spent.Equal(trades[0].GetTotalCost()) {
+		t.Fatalf("spent %s on a fill costing %s", spent, trades[0].GetTotalCost())
+	}
+
+	if held := e.positionQuantity(user.ID, "AAPL"); !held.Equal(decimal.NewFromInt(position)) {
+		t.Fatalf("holding %s AAPL, want %d", held, position)
+	}
+}
+
+func TestExecuteTradeHandlesBrokerFaults(t *testing.T) {
+	tests := []struct {
+		name     string
+		faults   alpaca.FaultConfig
+		err      error
+		status   models.TradeStatus
+		position int64
+	}{
+		{"reject", alpaca.FaultConfig{RejectRate: 1}, alpaca.ErrOrderRejected, models.TradeStatusRejected, 0},
+		{"outage", alpaca.FaultConfig{Outages: []alpaca.OutageWindow{{Start: 0, End: time.Hour}}}, alpaca.ErrBrokerUnavailable, models.TradeStatusRejected, 0},
+		{"timeout", alpaca.FaultConfig{TimeoutRate: 1, Timeout: time.Hour}, alpaca.ErrBrokerTimeout, models.TradeStatusPending, 0},
+		{"ack delay past deadline", alpaca.FaultConfig{AckDelayRate: 1, AckDelay: time.Hour}, alpaca.ErrBrokerTimeout, models.TradeStatusPending, 0},
+		{"duplicate fill", alpaca.FaultConfig{DuplicateFillRate: 1}, nil, models.TradeStatusFilled, 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, user := newTestEngine(t, tt.faults)
+
+			err := e.executeTrade(context.Background(), testBuy(user), user)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("got %v, want %v", err, tt.err)
+			}
+			assertBooked(t, e, user, tt.status, tt.position)
+		})
+	}
+}
+
+func TestReconcilePendingTrades(t *testing.T) {
+	for _, faults := range []alpaca.FaultConfig{
+		{TimeoutRate: 1, Timeout: time.Hour},
+		{AckDelayRate: 1, AckDelay: time.Hour},
+	} {
+		e, user := newTestEngine(t, faults)
+		if err := e.executeTrade(context.Background(), testBuy(user), user); !errors.Is(err, alpaca.ErrBrokerTimeout) {
+			t.Fatalf("got %v, want ErrBrokerTimeout", err)
+		}
+
+		// The broker filled the order, so reconciling applies the fill once
+		e.reconcilePendingTrades(context.Background(), user)
+		assertBooked(t, e, user, models.TradeStatusFilled, 10)
+		e.reconcilePendingTrades(context.Background(), user)
+		assertBooked(t, e, user, models.TradeStatusFilled, 10)
+	}
+}
+
+func TestReconcileExpiresUnknownOrders(t *testing.T) {
+	e, user := newTestEngine(t, alpaca.FaultConfig{})
+	trade := testBuy(user)
+	trade.AlpacaOrderID = "mock_lost"
+	if err := e.db.CreateTrade(trade); err != nil {
+		t.Fatal(err)
+	}
+
+	e.reconcilePendingTrades(context.Background(), user)
+	assertBooked(t, e, user, models.TradeStatusExpired, 0)
+}
+
+func TestReconcileUnwindsAbortedLeg(t *testing.T) {
+	e, user := newTestEngine(t, alpaca.FaultConfig{TimeoutRate: 1, Timeout: time.Hour})
+	leg := testBuy(user)
+	if err := e.executeTrade(context.Background(), leg, user); !errors.Is(err, alpaca.ErrBrokerTimeout) {
+		t.Fatalf("got %v, want ErrBrokerTimeout", err)
+	}
+	leg.Notes = abortedLegNote + leg.Notes
+	if err := e.db.UpdateTrade(leg); err != nil {
+		t.Fatal(err)
+	}
+
+	// The unwind goes to a healthy broker
+	e.broker = alpaca.NewFaultInjector(e.alpacaClient, alpaca.FaultConfig{Seed: 1})
+	e.reconcilePendingTrades(context.Background(), user)
+
+	if held := e.positionQuantity(user.ID, "AAPL"); !held.IsZero() {
+		t.Fatalf("aborted leg left %s AAPL open", held)
+	}
+	trades, err := e.db.GetTradesByStatus(user.ID, models.TradeStatusFilled)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(trades) != 2 || trades[1].Side != models.OrderSideSell {
+		t.Fatalf("filled trades %+v, want the leg and its unwind", trades)
+	}
+}
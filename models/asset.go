@@ -0,0 +1,91 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type AssetClass string
+
+const (
+	// Asset Classes
+	AssetClassEquity AssetClass = "us_equity"
+	AssetClassCrypto AssetClass = "crypto"
+)
+
+type Asset struct {
+	Symbol         string          `json:"symbol" db:"symbol"`
+	Class          AssetClass      `json:"class" db:"class"`
+	Exchange       string          `json:"exchange" db:"exchange"`
+	Sector         string          `json:"sector" db:"sector"`
+	QuoteCurrency  string          `json:"quote_currency" db:"quote_currency"`
+	TickSize       decimal.Decimal `json:"tick_size" db:"tick_size"`
+	MinLot         decimal.Decimal `json:"min_lot" db:"min_lot"` // smallest tradable quantity increment
+	Shortable      bool            `json:"shortable" db:"shortable"`
+	Marginable     bool            `json:"marginable" db:"marginable"`
+	ReferencePrice decimal.Decimal `json:"reference_price" db:"reference_price"` // seed price for the mock market
+	Active         bool            `json:"active" db:"active"`
+	UpdatedAt      time.Time       `json:"updated_at" db:"updated_at"`
+}
+
+func NewEquityAsset(symbol, exchange, sector string, referencePrice float64) *Asset {
+	return &Asset{
+		Symbol:         symbol,
+		Class:          AssetClassEquity,
+		Exchange:       exchange,
+		Sector:         sector,
+		QuoteCurrency:  "USD",
+		TickSize:       decimal.NewFromFloat(0.01),
+		MinLot:         decimal.NewFromInt(1),
+		Shortable:      true,
+		Marginable:     true,
+		ReferencePrice: decimal.NewFromFloat(referencePrice),
+		Active:         true,
+		UpdatedAt:      time.Now(),
+	}
+}
+
+// NewCryptoAsset creates a crypto pair such as BTC/USD. The quote currency is
+// taken from the part after the slash.
+func NewCryptoAsset(symbol string, minLot, referencePrice float64) *Asset {
+	quote := "USD"
+	if idx := strings.Index(symbol, "/"); idx >= 0 && idx < len(symbol)-1 {
+		quote = symbol[idx+1:]
+	}
+	return &Asset{
+		Symbol:         symbol,
+		Class:          AssetClassCrypto,
+		Exchange:       "CRYPTO",
+		Sector:         "Crypto",
+		QuoteCurrency:  quote,
+		TickSize:       decimal.NewFromFloat(0.01),
+		MinLot:         decimal.NewFromFloat(minLot),
+		Shortable:      false,
+		Marginable:     false,
+		ReferencePrice: decimal.NewFromFloat(referencePrice),
+		Active:         true,
+		UpdatedAt:      time.Now(),
+	}
+}
+
+// RoundQuantity truncates a quantity down to a whole number of lots
+func (a *Asset) RoundQuantity(quantity decimal.Decimal) decimal.Decimal {
+	if !a.MinLot.IsPositive() {
+		return quantity
+	}
+	return quantity.Div(a.MinLot).Floor().Mul(a.MinLot)
+}
+
+// RoundPrice rounds a price to the nearest tick
+func (a *Asset) RoundPrice(price decimal.Decimal) decimal.Decimal {
+	if !a.TickSize.IsPositive() {
+		return price
+	}
+	return price.Div(a.TickSize).Round(0).Mul(a.TickSize)
+}
+
+func (a *Asset) IsFractionable() bool {
+	return a.MinLot.LessThan(decimal.NewFromInt(1))
+}
@@ -0,0 +1,93 @@
+package models
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type CorporateActionType string
+type LedgerEntryType string
+
+const (
+	// Corporate Action Types
+	CorporateActionSplit    CorporateActionType = "split"
+	CorporateActionDividend CorporateActionType = "dividend"
+
+	// Ledger Entry Types
+	LedgerEntryDividend LedgerEntryType = "dividend"
+	LedgerEntrySplit    LedgerEntryType = "split"
+)
+
+type CorporateAction struct {
+	ID        int64               `json:"id" db:"id"`
+	Symbol    string              `json:"symbol" db:"symbol"`
+	Type      CorporateActionType `json:"type" db:"type"`
+	ExDate    time.Time           `json:"ex_date" db:"ex_date"`
+	PayDate   time.Time           `json:"pay_date" db:"pay_date"`
+	Ratio     decimal.Decimal     `json:"ratio" db:"ratio"`   // splits: new shares per old share
+	Amount    decimal.Decimal     `json:"amount" db:"amount"` // dividends: cash per share
+	CreatedAt time.Time           `json:"created_at" db:"created_at"`
+}
+
+// Entitlement is one user's share of a corporate action. The quantity is
+// snapshotted as of the ex-date, however late the action is processed.
+type Entitlement struct {
+	ActionID  int64           `json:"action_id" db:"action_id"`
+	UserID    int64           `json:"user_id" db:"user_id"`
+	Quantity  decimal.Decimal `json:"quantity" db:"quantity"` // shares held before the ex-date
+	Applied   bool            `json:"applied" db:"applied"`   // ex-date processing done
+	Paid      bool            `json:"paid" db:"paid"`         // dividend cash credited
+	UpdatedAt time.Time       `json:"updated_at" db:"updated_at"`
+
+	Action *CorporateAction `json:"-"`
+}
+
+type LedgerEntry struct {
+	ID          int64           `json:"id" db:"id"`
+	UserID      int64           `json:"user_id" db:"user_id"`
+	Type        LedgerEntryType `json:"type" db:"type"`
+	Symbol      string          `json:"symbol" db:"symbol"`
+	Amount      decimal.Decimal `json:"amount" db:"amount"`     // cash change
+	Quantity    decimal.Decimal `json:"quantity" db:"quantity"` // position change
+	Reference   int64           `json:"reference" db:"reference"`
+	Description string          `json:"description" db:"description"`
+	CreatedAt   time.Time       `json:"created_at" db:"created_at"`
+}
+
+func NewSplit(symbol string, exDate time.Time, ratio decimal.Decimal) *CorporateAction {
+	return &CorporateAction{
+		Symbol:    symbol,
+		Type:      CorporateActionSplit,
+		ExDate:    exDate,
+		PayDate:   exDate,
+		Ratio:     ratio,
+		CreatedAt: time.Now(),
+	}
+}
+
+func NewDividend(symbol string, exDate, payDate time.Time, amount decimal.Decimal) *CorporateAction {
+	return &CorporateAction{
+		Symbol:    symbol,
+		Type:      CorporateActionDividend,
+		ExDate:    exDate,
+		PayDate:   payDate,
+		Ratio:     decimal.NewFromInt(1),
+		Amount:    amount,
+		CreatedAt: time.Now(),
+	}
+}
+
+// ApplySplit rescales the entitled shares, those held before the ex-date,
+// by the split ratio while keeping the position's cost basis unchanged
+func (p *Portfolio) ApplySplit(entitled, ratio decimal.Decimal) {
+	if entitled.IsZero() || !ratio.IsPositive() {
+		return
+	}
+	cost := p.Quantity.Mul(p.AveragePrice)
+	p.Quantity = p.Quantity.Add(entitled.Mul(ratio.Sub(decimal.NewFromInt(1))))
+	if !p.Quantity.IsZero() {
+		p.AveragePrice = cost.Div(p.Quantity)
+	}
+	p.UpdatedAt = time.Now()
+}
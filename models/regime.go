@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// Regime is the market condition a symbol trades in
+type Regime string
+
+const (
+	RegimeTrend          Regime = "trend"           // directional, persistent moves
+	RegimeRange          Regime = "range"           // no direction, prices revert
+	RegimeHighVolatility Regime = "high_volatility" // realized volatility well above normal
+)
+
+// RegimeRecord is a change of a symbol's regime and the readings behind it
+type RegimeRecord struct {
+	ID              int64     `json:"id" db:"id"`
+	Symbol          string    `json:"symbol" db:"symbol"`
+	Regime          Regime    `json:"regime" db:"regime"`
+	ADX             float64   `json:"adx" db:"adx"`
+	Volatility      float64   `json:"volatility" db:"volatility"`             // standard deviation of recent returns
+	VolatilityRatio float64   `json:"volatility_ratio" db:"volatility_ratio"` // recent over long-run volatility
+	Hurst           float64   `json:"hurst" db:"hurst"`
+	CreatedAt       time.Time `json:"created_at" db:"created_at"`
+}
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// ShadowTrade is a hypothetical fill of a strategy running in shadow mode.
+// It only ever touches the strategy's virtual portfolio.
+type ShadowTrade struct {
+	ID        int64           `json:"id" db:"id"`
+	Strategy  string          `json:"strategy" db:"strategy"`
+	Symbol    string          `json:"symbol" db:"symbol"`
+	Side      OrderSide       `json:"side" db:"side"`
+	Quantity  decimal.Decimal `json:"quantity" db:"quantity"`
+	Price     decimal.Decimal `json:"price" db:"price"` // fill price including simulated slippage
+	Notes     string          `json:"notes" db:"notes"`
+	CreatedAt time.Time       `json:"created_at" db:"created_at"`
+}
+
+// EquitySnapshot records an account's value at the end of a trading cycle.
+// Account is "live" for the paper account and "shadow:<strategy>" for a
+// shadow portfolio.
+type EquitySnapshot struct {
+	ID        int64           `json:"id" db:"id"`
+	Account   string          `json:"account" db:"account"`
+	Cash      decimal.Decimal `json:"cash" db:"cash"`
+	Equity    decimal.Decimal `json:"equity" db:"equity"`
+	CreatedAt time.Time       `json:"created_at" db:"created_at"`
+}
+
+// LiveAccount is the EquitySnapshot account of the paper account
+const LiveAccount = "live"
+
+// ShadowAccount returns the EquitySnapshot account of a shadow strategy
+func ShadowAccount(strategy string) string {
+	return "shadow:" + strategy
+}
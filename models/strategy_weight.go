@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// StrategyWeight is a snapshot of a strategy's tracked performance and the
+// ensemble weight derived from it
+type StrategyWeight struct {
+	ID         int64     `json:"id" db:"id"`
+	Strategy   string    `json:"strategy" db:"strategy"`
+	Weight     float64   `json:"weight" db:"weight"`
+	HitRate    float64   `json:"hit_rate" db:"hit_rate"`       // exponentially weighted share of profitable signals
+	MeanReturn float64   `json:"mean_return" db:"mean_return"` // exponentially weighted signal return
+	Volatility float64   `json:"volatility" db:"volatility"`   // exponentially weighted std dev of signal returns
+	Samples    int64     `json:"samples" db:"samples"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+}
+
+// RiskAdjustedReturn returns the mean signal return per unit of volatility
+func (w *StrategyWeight) RiskAdjustedReturn() float64 {
+	if w.Volatility == 0 {
+		return 0
+	}
+	return w.MeanReturn / w.Volatility
+}
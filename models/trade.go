@@ -9,6 +9,7 @@ import (
 type TradeType string
 type TradeStatus string
 type OrderSide string
+type SignalDecision string
 
 const (
 	// Trade Types
@@ -26,6 +27,15 @@ const (
 	// Order Sides
 	OrderSideBuy  OrderSide = "buy"
 	OrderSideSell OrderSide = "sell"
+
+	// Signal Decisions
+	SignalDecisionTraded   SignalDecision = "traded"   // contributed to an executed trade
+	SignalDecisionFailed   SignalDecision = "failed"   // contributed to a trade that failed to execute
+	SignalDecisionOutvoted SignalDecision = "outvoted" // the trade went the other way
+	SignalDecisionNoTrade  SignalDecision = "no_trade" // no trade was placed for the symbol
+	SignalDecisionShadow   SignalDecision = "shadow"   // from a shadow strategy, traded only virtually
+
+	SignalDecisionRegimeDisabled SignalDecision = "regime_disabled" // dropped because its style is off in the symbol's regime
 )
 
 type Trade struct {
@@ -55,6 +65,41 @@ type TradingSignal struct {
 	Price     decimal.Decimal `json:"price" db:"price"`
 	Strategy  string          `json:"strategy" db:"strategy"`
 	CreatedAt time.Time       `json:"created_at" db:"created_at"`
+
+	// TargetWeight is an optional target fraction of portfolio value, set by
+	// strategies that allocate across symbols
+	TargetWeight float64 `json:"target_weight,omitempty"`
+
+	// RawStrength is the strength the strategy reported before calibration
+	// replaced Strength with an empirical probability of a profitable move
+	RawStrength float64 `json:"raw_strength,omitempty" db:"raw_strength"`
+
+	// Indicators holds the indicator values the signal was based on and
+	// Reason explains in words why it fired
+	Indicators map[string]float64 `json:"indicators,omitempty" db:"indicators"`
+	Reason     string             `json:"reason,omitempty" db:"reason"`
+
+	// Decision records what the engine did with the signal, and TradeID the
+	// trade it led to, if any
+	Decision SignalDecision `json:"decision,omitempty" db:"decision"`
+	TradeID  int64          `json:"trade_id,omitempty" db:"trade_id"`
+
+	// Legs, when set, make this a multi-leg signal whose orders are entered
+	// and exited together; Symbol and Signal then describe the first leg
+	Legs []SignalLeg `json:"legs,omitempty"`
+}
+
+// SignalLeg is one order of a multi-leg signal
+type SignalLeg struct {
+	Symbol string    `json:"symbol"`
+	Side   OrderSide `json:"side"`
+
+	// Weight is the leg's fraction of the signal's gross notional, used to
+	// size entries
+	Weight float64 `json:"weight,omitempty"`
+
+	// Quantity is an explicit size, used to close legs opened earlier
+	Quantity decimal.Decimal `json:"quantity"`
 }
 
 type MarketData struct {
@@ -0,0 +1,90 @@
+// Package patterns recognizes candlestick patterns in OHLC bars: single
+// candles (doji, hammer, shooting star), two-candle patterns (engulfing,
+// inside and outside bars) and three-candle patterns (morning and evening
+// star, three white soldiers and three black crows).
+package patterns
+
+import (
+	"math"
+
+	"github.com/MunishMummadi/mock-trade-algorithm/alpaca"
+	"github.com/MunishMummadi/mock-trade-algorithm/config"
+)
+
+// Tolerances controls how strictly candle shapes must match. Body and
+// shadow sizes are measured as fractions of the candle's high-low range.
+type Tolerances struct {
+	DojiBody       float64 // largest body of a doji
+	LongBody       float64 // smallest body of a long candle
+	ShadowRatio    float64 // smallest long shadow of a hammer or shooting star, in bodies
+	OppositeShadow float64 // largest opposite shadow of a hammer or shooting star
+	StarBody       float64 // largest star body in morning and evening stars, as a fraction of the first body
+	PriceTolerance float64 // relative slack when comparing prices, so equal opens and closes count as touching
+}
+
+// DefaultTolerances returns commonly used thresholds
+func DefaultTolerances() Tolerances {
+	return Tolerances{
+		DojiBody:       0.1,
+		LongBody:       0.6,
+		ShadowRatio:    2.0,
+		OppositeShadow: 0.15,
+		StarBody:       0.3,
+		PriceTolerance: 0.001,
+	}
+}
+
+// TolerancesFromConfig builds the tolerances from application config
+func TolerancesFromConfig(cfg *config.Config) Tolerances {
+	return Tolerances{
+		DojiBody:       cfg.PatternDojiBody,
+		LongBody:       cfg.PatternLongBody,
+		ShadowRatio:    cfg.PatternShadowRatio,
+		OppositeShadow: cfg.PatternOppositeShadow,
+		StarBody:       cfg.PatternStarBody,
+		PriceTolerance: cfg.PatternPriceTolerance,
+	}
+}
+
+// candle wraps a bar with shape measurements
+type candle struct {
+	alpaca.MockBar
+}
+
+func (c candle) body() float64 {
+	return math.Abs(c.Close - c.Open)
+}
+
+func (c candle) span() float64 {
+	return c.High - c.Low
+}
+
+func (c candle) upperShadow() float64 {
+	return c.High - math.Max(c.Open, c.Close)
+}
+
+func (c candle) lowerShadow() float64 {
+	return math.Min(c.Open, c.Close) - c.Low
+}
+
+func (c candle) midpoint() float64 {
+	return (c.Open + c.Close) / 2
+}
+
+func (c candle) bullish() bool {
+	return c.Close > c.Open
+}
+
+func (c candle) bearish() bool {
+	return c.Close < c.Open
+}
+
+// long reports whether the body fills most of the range
+func (c candle) long(tol Tolerances) bool {
+	return c.span() > 0 && c.body() >= tol.LongBody*c.span()
+}
+
+// atMost reports whether a <= b within the price tolerance
+func atMost(a, b float64, tol Tolerances) bool {
+	return a <= b+math.Abs(b)*tol.PriceTolerance
+}
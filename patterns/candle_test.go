@@ -0,0 +1,72 @@
+package patterns
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/MunishMummadi/mock-trade-algorithm/config"
+)
+
+// loadConfig loads the application config from the environment with
+// placeholder credentials and the given overrides
+func loadConfig(t *testing.T, env map[string]string) (*config.Config, error) {
+	t.Helper()
+	t.Setenv("ALPACA_API_KEY", "test")
+	t.Setenv("ALPACA_API_SECRET", "test")
+	for key, value := range env {
+		t.Setenv(key, value)
+	}
+	return config.Load()
+}
+
+func TestTolerancesFromConfig(t *testing.T) {
+	cfg, err := loadConfig(t, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := TolerancesFromConfig(cfg); got != DefaultTolerances() {
+		t.Fatalf("default settings give %+v, want %+v", got, DefaultTolerances())
+	}
+
+	cfg, err = loadConfig(t, map[string]string{
+		"PATTERN_DOJI_BODY":       "0.05",
+		"PATTERN_LONG_BODY":       "0.7",
+		"PATTERN_SHADOW_RATIO":    "3",
+		"PATTERN_OPPOSITE_SHADOW": "0.1",
+		"PATTERN_STAR_BODY":       "0.25",
+		"PATTERN_PRICE_TOLERANCE": "0",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Tolerances{DojiBody: 0.05, LongBody: 0.7, ShadowRatio: 3, OppositeShadow: 0.1, StarBody: 0.25}
+	if got := TolerancesFromConfig(cfg); got != want {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestPatternSettingsAreValidated(t *testing.T) {
+	tests := []struct {
+		key, value string
+	}{
+		{"PATTERN_DOJI_BODY", "0"},
+		{"PATTERN_DOJI_BODY", "0.7"}, // not below the default long body
+		{"PATTERN_LONG_BODY", "1.5"},
+		{"PATTERN_SHADOW_RATIO", "0"},
+		{"PATTERN_STAR_BODY", "-1"},
+		{"PATTERN_OPPOSITE_SHADOW", "1"},
+		{"PATTERN_OPPOSITE_SHADOW", "-0.1"},
+		{"PATTERN_PRICE_TOLERANCE", "-0.001"},
+		{"PATTERN_MIN_MOVE", "-0.01"},
+		{"PATTERN_TREND_PERIOD", "0"},
+		{"PATTERN_VOLUME_RATIO", "0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key+"="+tt.value, func(t *testing.T) {
+			_, err := loadConfig(t, map[string]string{tt.key: tt.value})
+			if err == nil || !strings.Contains(err.Error(), tt.key) {
+				t.Errorf("got %v, want an error naming %s", err, tt.key)
+			}
+		})
+	}
+}
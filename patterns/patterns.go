@@ -0,0 +1,215 @@
+package patterns
+
+import "github.com/MunishMummadi/mock-trade-algorithm/alpaca"
+
+// Direction is the move a pattern points to
+type Direction int
+
+const (
+	Neutral Direction = iota // indecision: doji, inside bars
+	Bullish
+	Bearish
+)
+
+func (d Direction) String() string {
+	switch d {
+	case Bullish:
+		return "bullish"
+	case Bearish:
+		return "bearish"
+	default:
+		return "neutral"
+	}
+}
+
+// Pattern names
+const (
+	Doji               = "doji"
+	Hammer             = "hammer"
+	ShootingStar       = "shooting_star"
+	BullishEngulfing   = "bullish_engulfing"
+	BearishEngulfing   = "bearish_engulfing"
+	MorningStar        = "morning_star"
+	EveningStar        = "evening_star"
+	ThreeWhiteSoldiers = "three_white_soldiers"
+	ThreeBlackCrows    = "three_black_crows"
+	InsideBar          = "inside_bar"
+	OutsideBar         = "outside_bar"
+)
+
+// Match is a pattern found in a run of bars
+type Match struct {
+	Pattern   string
+	Direction Direction
+	Start     int // index of the pattern's first bar
+	End       int // index of the bar that completes it
+}
+
+// detector recognizes one pattern in its last size candles
+type detector struct {
+	name  string
+	size  int
+	match func(c []candle, tol Tolerances) (Direction, bool)
+}
+
+var detectors = []detector{
+	{Doji, 1, doji},
+	{Hammer, 1, hammer},
+	{ShootingStar, 1, shootingStar},
+	{BullishEngulfing, 2, bullishEngulfing},
+	{BearishEngulfing, 2, bearishEngulfing},
+	{InsideBar, 2, insideBar},
+	{OutsideBar, 2, outsideBar},
+	{MorningStar, 3, morningStar},
+	{EveningStar, 3, eveningStar},
+	{ThreeWhiteSoldiers, 3, threeWhiteSoldiers},
+	{ThreeBlackCrows, 3, threeBlackCrows},
+}
+
+// At returns the patterns completed by bar i
+func At(bars []alpaca.MockBar, i int, tol Tolerances) []Match {
+	if i < 0 || i >= len(bars) {
+		return nil
+	}
+
+	var matches []Match
+	for _, d := range detectors {
+		start := i - d.size + 1
+		if start < 0 {
+			continue
+		}
+
+		candles := make([]candle, d.size)
+		for j := range candles {
+			candles[j] = candle{bars[start+j]}
+		}
+		if direction, ok := d.match(candles, tol); ok {
+			matches = append(matches, Match{Pattern: d.name, Direction: direction, Start: start, End: i})
+		}
+	}
+	return matches
+}
+
+// Scan returns every pattern in the bars, ordered by the bar completing it
+func Scan(bars []alpaca.MockBar, tol Tolerances) []Match {
+	var matches []Match
+	for i := range bars {
+		matches = append(matches, At(bars, i, tol)...)
+	}
+	return matches
+}
+
+// doji has almost no body
+func doji(c []candle, tol Tolerances) (Direction, bool) {
+	return Neutral, c[0].span() > 0 && c[0].body() <= tol.DojiBody*c[0].span()
+}
+
+// hammer has a long lower shadow and almost no upper shadow
+func hammer(c []candle, tol Tolerances) (Direction, bool) {
+	k := c[0]
+	return Bullish, k.span() > 0 &&
+		k.lowerShadow() >= tol.ShadowRatio*k.body() &&
+		k.upperShadow() <= tol.OppositeShadow*k.span()
+}
+
+// shootingStar has a long upper shadow and almost no lower shadow
+func shootingStar(c []candle, tol Tolerances) (Direction, bool) {
+	k := c[0]
+	return Bearish, k.span() > 0 &&
+		k.upperShadow() >= tol.ShadowRatio*k.body() &&
+		k.lowerShadow() <= tol.OppositeShadow*k.span()
+}
+
+// bullishEngulfing is a rising body that covers the previous falling one
+func bullishEngulfing(c []candle, tol Tolerances) (Direction, bool) {
+	prev, cur := c[0], c[1]
+	return Bullish, prev.bearish() && cur.bullish() &&
+		atMost(cur.Open, prev.Close, tol) && atMost(prev.Open, cur.Close, tol) &&
+		cur.body() > prev.body()
+}
+
+// bearishEngulfing is a falling body that covers the previous rising one
+func bearishEngulfing(c []candle, tol Tolerances) (Direction, bool) {
+	prev, cur := c[0], c[1]
+	return Bearish, prev.bullish() && cur.bearish() &&
+		atMost(prev.Close, cur.Open, tol) && atMost(cur.Close, prev.Open, tol) &&
+		cur.body() > prev.body()
+}
+
+// insideBar trades entirely within the previous bar's range
+func insideBar(c []candle, tol Tolerances) (Direction, bool) {
+	prev, cur := c[0], c[1]
+	return Neutral, cur.High < prev.High && cur.Low > prev.Low
+}
+
+// outsideBar exceeds the previous bar's range on both sides; its direction
+// is the candle's color
+func outsideBar(c []candle, tol Tolerances) (Direction, bool) {
+	prev, cur := c[0], c[1]
+	if cur.High <= prev.High || cur.Low >= prev.Low {
+		return Neutral, false
+	}
+
+	switch {
+	case cur.bullish():
+		return Bullish, true
+	case cur.bearish():
+		return Bearish, true
+	default:
+		return Neutral, true
+	}
+}
+
+// morningStar is a long falling candle, a small star at or below its close
+// and a rising candle closing above the first one's midpoint
+func morningStar(c []candle, tol Tolerances) (Direction, bool) {
+	first, star, last := c[0], c[1], c[2]
+	return Bullish, first.bearish() && first.long(tol) &&
+		star.body() <= tol.StarBody*first.body() &&
+		atMost(star.midpoint(), first.Close, tol) &&
+		last.bullish() && last.Close > first.midpoint()
+}
+
+// eveningStar is a long rising candle, a small star at or above its close
+// and a falling candle closing below the first one's midpoint
+func eveningStar(c []candle, tol Tolerances) (Direction, bool) {
+	first, star, last := c[0], c[1], c[2]
+	return Bearish, first.bullish() && first.long(tol) &&
+		star.body() <= tol.StarBody*first.body() &&
+		atMost(first.Close, star.midpoint(), tol) &&
+		last.bearish() && last.Close < first.midpoint()
+}
+
+// threeWhiteSoldiers is three long rising candles, each opening within the
+// previous body and closing higher
+func threeWhiteSoldiers(c []candle, tol Tolerances) (Direction, bool) {
+	for i, k := range c {
+		if !k.bullish() || !k.long(tol) {
+			return Bullish, false
+		}
+		if i > 0 {
+			prev := c[i-1]
+			if !atMost(prev.Open, k.Open, tol) || !atMost(k.Open, prev.Close, tol) || k.Close <= prev.Close {
+				return Bullish, false
+			}
+		}
+	}
+	return Bullish, true
+}
+
+// threeBlackCrows is three long falling candles, each opening within the
+// previous body and closing lower
+func threeBlackCrows(c []candle, tol Tolerances) (Direction, bool) {
+	for i, k := range c {
+		if !k.bearish() || !k.long(tol) {
+			return Bearish, false
+		}
+		if i > 0 {
+			prev := c[i-1]
+			if !atMost(prev.Close, k.Open, tol) || !atMost(k.Open, prev.Open, tol) || k.Close >= prev.Close {
+				return Bearish, false
+			}
+		}
+	}
+	return Bearish, true
+}
@@ -0,0 +1,106 @@
+package patterns
+
+import (
+	"testing"
+
+	"github.com/MunishMummadi/mock-trade-algorithm/alpaca"
+)
+
+func ohlc(open, high, low, close float64) alpaca.MockBar {
+	return alpaca.MockBar{Open: open, High: high, Low: low, Close: close, Volume: 1000}
+}
+
+func TestPatternsOnHandBuiltBars(t *testing.T) {
+	tests := []struct {
+		name      string
+		bars      []alpaca.MockBar
+		pattern   string
+		direction Direction
+		want      bool
+	}{
+		{"doji", []alpaca.MockBar{ohlc(100, 102, 98, 100.2)}, Doji, Neutral, true},
+		{"doji body too large", []alpaca.MockBar{ohlc(100, 102, 98, 100.5)}, Doji, Neutral, false},
+
+		{"hammer", []alpaca.MockBar{ohlc(100, 100.2, 96, 100.1)}, Hammer, Bullish, true},
+		{"hammer upper shadow too long", []alpaca.MockBar{ohlc(100, 101.5, 96, 100.1)}, Hammer, Bullish, false},
+
+		{"shooting star", []alpaca.MockBar{ohlc(100, 104, 99.8, 99.9)}, ShootingStar, Bearish, true},
+		{"shooting star upper shadow too short", []alpaca.MockBar{ohlc(100, 101, 97.9, 98)}, ShootingStar, Bearish, false},
+
+		{"bullish engulfing", []alpaca.MockBar{ohlc(102, 102.5, 99.5, 100), ohlc(99.5, 103.5, 99, 103)}, BullishEngulfing, Bullish, true},
+		{"bullish engulfing short of the prior open", []alpaca.MockBar{ohlc(102, 102.5, 99.5, 100), ohlc(99.5, 102, 99, 101.5)}, BullishEngulfing, Bullish, false},
+
+		{"bearish engulfing", []alpaca.MockBar{ohlc(100, 102.5, 99.5, 102), ohlc(102.5, 103, 98.5, 99)}, BearishEngulfing, Bearish, true},
+		{"bearish engulfing short of the prior open", []alpaca.MockBar{ohlc(100, 102.5, 99.5, 102), ohlc(102.5, 103, 100, 100.5)}, BearishEngulfing, Bearish, false},
+
+		{"inside bar", []alpaca.MockBar{ohlc(98, 105, 95, 102), ohlc(100, 104, 96, 101)}, InsideBar, Neutral, true},
+		{"inside bar touching the prior high", []alpaca.MockBar{ohlc(98, 105, 95, 102), ohlc(100, 105, 96, 101)}, InsideBar, Neutral, false},
+
+		{"bullish outside bar", []alpaca.MockBar{ohlc(98, 104, 96, 102), ohlc(96, 105, 95, 104.5)}, OutsideBar, Bullish, true},
+		{"bearish outside bar", []alpaca.MockBar{ohlc(98, 104, 96, 102), ohlc(104.5, 105, 95, 96)}, OutsideBar, Bearish, true},
+		{"outside bar touching the prior low", []alpaca.MockBar{ohlc(98, 104, 96, 102), ohlc(96, 105, 96, 104.5)}, OutsideBar, Bullish, false},
+
+		{"morning star", []alpaca.MockBar{ohlc(110, 111, 99, 100), ohlc(99, 100, 98, 99.5), ohlc(100, 107, 99.5, 106)}, MorningStar, Bullish, true},
+		{"morning star closing below the midpoint", []alpaca.MockBar{ohlc(110, 111, 99, 100), ohlc(99, 100, 98, 99.5), ohlc(100, 105, 99.5, 104)}, MorningStar, Bullish, false},
+
+		{"evening star", []alpaca.MockBar{ohlc(100, 111, 99, 110), ohlc(111, 112, 110, 110.5), ohlc(110, 110.5, 103, 104)}, EveningStar, Bearish, true},
+		{"evening star closing above the midpoint", []alpaca.MockBar{ohlc(100, 111, 99, 110), ohlc(111, 112, 110, 110.5), ohlc(110, 110.5, 105, 106)}, EveningStar, Bearish, false},
+
+		{"three white soldiers", []alpaca.MockBar{ohlc(100, 104.5, 99.5, 104), ohlc(102, 106.5, 101.5, 106), ohlc(105, 109.5, 104.5, 109)}, ThreeWhiteSoldiers, Bullish, true},
+		{"three white soldiers opening above the prior body", []alpaca.MockBar{ohlc(100, 104.5, 99.5, 104), ohlc(102, 106.5, 101.5, 106), ohlc(107, 111.5, 106.5, 111)}, ThreeWhiteSoldiers, Bullish, false},
+
+		{"three black crows", []alpaca.MockBar{ohlc(110, 110.5, 105.5, 106), ohlc(108, 108.5, 103.5, 104), ohlc(105, 105.5, 100.5, 101)}, ThreeBlackCrows, Bearish, true},
+		{"three black crows opening below the prior body", []alpaca.MockBar{ohlc(110, 110.5, 105.5, 106), ohlc(108, 108.5, 103.5, 104), ohlc(103, 103.5, 98.5, 99)}, ThreeBlackCrows, Bearish, false},
+
+		// Opens within PriceTolerance of the prior close count as touching it
+		{"engulfing within price tolerance", []alpaca.MockBar{ohlc(102, 102.5, 99.5, 100), ohlc(100.05, 103.5, 99.5, 103)}, BullishEngulfing, Bullish, true},
+		{"engulfing past price tolerance", []alpaca.MockBar{ohlc(102, 102.5, 99.5, 100), ohlc(100.2, 103.5, 99.5, 103)}, BullishEngulfing, Bullish, false},
+	}
+
+	for _, tt := range tests {
+		last := len(tt.bars) - 1
+		var found *Match
+		for _, match := range At(tt.bars, last, DefaultTolerances()) {
+			if match.Pattern == tt.pattern {
+				found = &match
+			}
+		}
+
+		if !tt.want {
+			if found != nil {
+				t.Errorf("%s: unexpected %s match %+v", tt.name, tt.pattern, *found)
+			}
+			continue
+		}
+		if found == nil {
+			t.Errorf("%s: no %s match", tt.name, tt.pattern)
+			continue
+		}
+		if found.Direction != tt.direction || found.Start != 0 || found.End != last {
+			t.Errorf("%s: got %+v, want %s over bars 0-%d", tt.name, *found, tt.direction, last)
+		}
+	}
+}
+
+func TestAtMostAllowsPriceTolerance(t *testing.T) {
+	tol := DefaultTolerances()
+	tests := []struct {
+		a, b      float64
+		tolerance float64
+		want      bool
+	}{
+		{99, 100, 0, true},
+		{100, 100, 0, true},
+		{100.05, 100, 0, false},
+		{100.05, 100, 0.001, true},
+		{100.1, 100, 0.001, true},
+		{100.2, 100, 0.001, false},
+		{-99.95, -100, 0.001, true},
+	}
+	for _, tt := range tests {
+		tol.PriceTolerance = tt.tolerance
+		if got := atMost(tt.a, tt.b, tol); got != tt.want {
+			t.Errorf("atMost(%v, %v) with tolerance %v = %v, want %v", tt.a, tt.b, tt.tolerance, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+// Package regime classifies each symbol's market as trending, ranging or
+// highly volatile and decides which strategy styles trade in each regime, so
+// trend-following and mean-reversion strategies stop cancelling each other
+// out in the vote.
+package regime
+
+import (
+	"math"
+
+	"github.com/MunishMummadi/mock-trade-algorithm/config"
+	"github.com/MunishMummadi/mock-trade-algorithm/indicators"
+	"github.com/MunishMummadi/mock-trade-algorithm/models"
+	"github.com/MunishMummadi/mock-trade-algorithm/strategies"
+)
+
+// Config controls regime classification and how regimes gate strategies
+type Config struct {
+	Enabled             bool
+	ADXPeriod           int
+	TrendADX            float64 // ADX at or above which the market votes trend
+	RangeADX            float64 // ADX at or below which the market votes range
+	VolatilityWindow    int     // returns in the recent volatility estimate
+	HighVolatilityRatio float64 // recent over long-run volatility that counts as high volatility
+	HurstTrend          float64 // Hurst exponent at or above which the market votes trend
+	HurstRange          float64 // Hurst exponent at or below which the market votes range
+	Confirm             int     // consecutive readings needed to change regime
+	Styles              string  // comma separated "strategy=style" overrides
+	Weights             string  // comma separated "regime:style=weight" overrides
+}
+
+// ConfigFromConfig builds the regime settings from application config
+func ConfigFromConfig(cfg *config.Config) Config {
+	return Config{
+		Enabled:             cfg.RegimeEnabled,
+		ADXPeriod:           int(cfg.RegimeADXPeriod),
+		TrendADX:            cfg.RegimeTrendADX,
+		RangeADX:            cfg.RegimeRangeADX,
+		VolatilityWindow:    int(cfg.RegimeVolatilityWindow),
+		HighVolatilityRatio: cfg.RegimeHighVolatilityRatio,
+		HurstTrend:          cfg.RegimeHurstTrend,
+		HurstRange:          cfg.RegimeHurstRange,
+		Confirm:             int(cfg.RegimeConfirm),
+		Styles:              cfg.StrategyStyles,
+		Weights:             cfg.RegimeWeights,
+	}
+}
+
+// Reading is one classification of a symbol's bars
+type Reading struct {
+	Regime          models.Regime
+	ADX             float64
+	Volatility      float64
+	VolatilityRatio float64
+	Hurst           float64 // NaN when there are too few bars
+}
+
+// Classify reads the regime of a feature frame. High volatility wins when
+// recent volatility reaches HighVolatilityRatio times the long-run level.
+// Otherwise ADX and the Hurst exponent each vote for trend or range, and
+// the side with more votes wins; a tie goes to trend if ADX is above the
+// midpoint of its two thresholds. It returns false when the frame is too
+// short for ADX or the volatility window.
+func Classify(features *strategies.Features, cfg Config) (Reading, bool) {
+	adx, _, _ := features.ADX(cfg.ADXPeriod)
+	returns := features.Returns()
+	if len(adx) == 0 || len(returns) < 2*cfg.VolatilityWindow {
+		return Reading{}, false
+	}
+
+	reading := Reading{
+		ADX:        adx[len(adx)-1],
+		Volatility: stddev(returns[len(returns)-cfg.VolatilityWindow:]),
+		Hurst:      math.NaN(),
+	}
+	if longRun := stddev(returns); longRun > 0 {
+		reading.VolatilityRatio = reading.Volatility / longRun
+	}
+	if hurst, ok := indicators.Hurst(features.Closes()); ok {
+		reading.Hurst = hurst
+	}
+
+	reading.Regime = decide(reading, cfg)
+	return reading, true
+}
+
+// decide picks the regime of a reading as described on Classify
+func decide(reading Reading, cfg Config) models.Regime {
+	if reading.VolatilityRatio >= cfg.HighVolatilityRatio {
+		return models.RegimeHighVolatility
+	}
+
+	var trendVotes, rangeVotes int
+	switch {
+	case reading.ADX >= cfg.TrendADX:
+		trendVotes++
+	case reading.ADX <= cfg.RangeADX:
+		rangeVotes++
+	}
+	switch {
+	case math.IsNaN(reading.Hurst):
+	case reading.Hurst >= cfg.HurstTrend:
+		trendVotes++
+	case reading.Hurst <= cfg.HurstRange:
+		rangeVotes++
+	}
+
+	switch {
+	case trendVotes > rangeVotes:
+		return models.RegimeTrend
+	case rangeVotes > trendVotes:
+		return models.RegimeRange
+	case reading.ADX >= (cfg.TrendADX+cfg.RangeADX)/2:
+		return models.RegimeTrend
+	default:
+		return models.RegimeRange
+	}
+}
+
+// stddev returns the sample standard deviation
+func stddev(values []float64) float64 {
+	if len(values) < 2 {
+		return 0
+	}
+
+	var mean float64
+	for _, v := range values {
+		mean += v
+	}
+	mean /= float64(len(values))
+
+	var squares float64
+	for _, v := range values {
+		squares += (v - mean) * (v - mean)
+	}
+	return math.Sqrt(squares / float64(len(values)-1))
+}
@@ -0,0 +1,126 @@
+package regime
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/MunishMummadi/mock-trade-algorithm/alpaca"
+	"github.com/MunishMummadi/mock-trade-algorithm/models"
+	"github.com/MunishMummadi/mock-trade-algorithm/strategies"
+)
+
+// testConfig mirrors the REGIME_* defaults
+func testConfig() Config {
+	return Config{
+		Enabled:             true,
+		ADXPeriod:           14,
+		TrendADX:            25,
+		RangeADX:            20,
+		VolatilityWindow:    20,
+		HighVolatilityRatio: 1.5,
+		HurstTrend:          0.6,
+		HurstRange:          0.4,
+		Confirm:             3,
+	}
+}
+
+// barsFrom builds daily bars from closes, each opening at the previous close
+// with a range of spread around its body
+func barsFrom(closes []float64, spread float64) *strategies.Features {
+	start := time.Date(2026, 1, 5, 16, 0, 0, 0, time.UTC)
+	bars := make([]alpaca.MockBar, len(closes))
+	for i, close := range closes {
+		open := close
+		if i > 0 {
+			open = closes[i-1]
+		}
+		bars[i] = alpaca.MockBar{
+			Timestamp: start.AddDate(0, 0, i),
+			Open:      open,
+			High:      math.Max(open, close) + spread,
+			Low:       math.Min(open, close) - spread,
+			Close:     close,
+			Volume:    1000,
+		}
+	}
+	return strategies.NewFeatures("TEST", bars)
+}
+
+func TestClassifyBars(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	const n = 200
+
+	trend := make([]float64, n)
+	zigzag := make([]float64, n)
+	calmThenWild := make([]float64, n)
+	price := 100.0
+	for i := 0; i < n; i++ {
+		trend[i] = 100 + float64(i) + rng.NormFloat64()*0.2
+		zigzag[i] = 100 + 2*math.Pow(-1, float64(i)) + rng.NormFloat64()*0.2
+
+		volatility := 0.005
+		if i >= n-20 {
+			volatility = 0.04
+		}
+		price *= 1 + rng.NormFloat64()*volatility
+		calmThenWild[i] = price
+	}
+
+	tests := []struct {
+		name   string
+		closes []float64
+		want   models.Regime
+	}{
+		{"steady rise", trend, models.RegimeTrend},
+		{"zigzag", zigzag, models.RegimeRange},
+		{"volatility spike", calmThenWild, models.RegimeHighVolatility},
+	}
+	for _, tt := range tests {
+		reading, ok := Classify(barsFrom(tt.closes, 0.1), testConfig())
+		if !ok {
+			t.Errorf("%s: not classified", tt.name)
+			continue
+		}
+		if reading.Regime != tt.want {
+			t.Errorf("%s: classified %s, want %s (reading %+v)", tt.name, reading.Regime, tt.want, reading)
+		}
+	}
+
+	// Too few returns for the long-run volatility
+	if reading, ok := Classify(barsFrom(trend[:30], 0.1), testConfig()); ok {
+		t.Errorf("classified 30 bars as %s, want too short", reading.Regime)
+	}
+}
+
+func TestDecideThresholds(t *testing.T) {
+	nan := math.NaN()
+	tests := []struct {
+		name    string
+		reading Reading
+		want    models.Regime
+	}{
+		{"volatility at the ratio", Reading{ADX: 40, Hurst: 0.7, VolatilityRatio: 1.5}, models.RegimeHighVolatility},
+		{"volatility below the ratio", Reading{ADX: 40, Hurst: 0.7, VolatilityRatio: 1.49}, models.RegimeTrend},
+
+		{"ADX at the trend threshold", Reading{ADX: 25, Hurst: nan}, models.RegimeTrend},
+		{"ADX at the range threshold", Reading{ADX: 20, Hurst: nan}, models.RegimeRange},
+		{"Hurst at the trend threshold", Reading{ADX: 22, Hurst: 0.6}, models.RegimeTrend},
+		{"Hurst at the range threshold", Reading{ADX: 23, Hurst: 0.4}, models.RegimeRange},
+		{"both vote trend", Reading{ADX: 30, Hurst: 0.7}, models.RegimeTrend},
+		{"both vote range", Reading{ADX: 10, Hurst: 0.3}, models.RegimeRange},
+
+		// Split and abstaining votes are settled by the ADX midpoint, 22.5
+		{"split votes above the midpoint", Reading{ADX: 30, Hurst: 0.3}, models.RegimeTrend},
+		{"split votes below the midpoint", Reading{ADX: 15, Hurst: 0.7}, models.RegimeRange},
+		{"no votes at the midpoint", Reading{ADX: 22.5, Hurst: 0.5}, models.RegimeTrend},
+		{"no votes below the midpoint", Reading{ADX: 22.4, Hurst: 0.5}, models.RegimeRange},
+		{"no votes without Hurst", Reading{ADX: 21, Hurst: nan}, models.RegimeRange},
+	}
+	for _, tt := range tests {
+		if got := decide(tt.reading, testConfig()); got != tt.want {
+			t.Errorf("%s: got %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package regime
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/MunishMummadi/mock-trade-algorithm/database"
+	"github.com/MunishMummadi/mock-trade-algorithm/models"
+	"github.com/MunishMummadi/mock-trade-algorithm/strategies"
+)
+
+// Detector tracks the regime of every symbol. A symbol changes regime only
+// after Confirm consecutive readings agree on the new one, so noise around a
+// threshold doesn't flip strategies on and off every cycle. Every change is
+// persisted, and the last regimes are restored on startup.
+type Detector struct {
+	db     *database.Database
+	config Config
+	policy *Policy
+
+	mu      sync.Mutex
+	symbols map[string]*symbolState
+}
+
+type symbolState struct {
+	current   models.Regime
+	candidate models.Regime
+	streak    int
+}
+
+func NewDetector(db *database.Database, cfg Config) (*Detector, error) {
+	policy, err := ParsePolicy(cfg.Styles, cfg.Weights)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Detector{
+		db:      db,
+		config:  cfg,
+		policy:  policy,
+		symbols: make(map[string]*symbolState),
+	}, nil
+}
+
+// InheritStyle gives a wrapper strategy the style of the strategy it wraps.
+// It must be called before the first trading cycle.
+func (d *Detector) InheritStyle(wrapper, inner string) {
+	d.policy.InheritStyle(wrapper, inner)
+}
+
+// Load restores each symbol's last persisted regime
+func (d *Detector) Load() error {
+	if !d.config.Enabled {
+		return nil
+	}
+
+	records, err := d.db.GetLatestRegimes()
+	if err != nil {
+		return fmt.Errorf("failed to load regimes: %w", err)
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, record := range records {
+		d.symbols[record.Symbol] = &symbolState{current: record.Regime}
+	}
+	return nil
+}
+
+// Update classifies a symbol's latest bars and returns its regime, which is
+// empty while the symbol has never had enough bars to classify
+func (d *Detector) Update(features *strategies.Features) models.Regime {
+	if !d.config.Enabled {
+		return ""
+	}
+
+	reading, ok := Classify(features, d.config)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	state, exists := d.symbols[features.Symbol]
+	if !exists {
+		state = &symbolState{}
+		d.symbols[features.Symbol] = state
+	}
+	if !ok {
+		return state.current
+	}
+
+	switch {
+	case reading.Regime == state.current:
+		state.candidate, state.streak = "", 0
+		return state.current
+	case reading.Regime == state.candidate:
+		state.streak++
+	default:
+		state.candidate, state.streak = reading.Regime, 1
+	}
+
+	// The first reading of a symbol is taken as is
+	if state.current != "" && state.streak < d.config.Confirm {
+		return state.current
+	}
+
+	previous := state.current
+	state.current, state.candidate, state.streak = reading.Regime, "", 0
+	d.record(features.Symbol, previous, reading)
+	return state.current
+}
+
+// record persists and logs a regime change
+func (d *Detector) record(symbol string, previous models.Regime, reading Reading) {
+	hurst := reading.Hurst
+	if math.IsNaN(hurst) {
+		hurst = 0
+	}
+
+	record := &models.RegimeRecord{
+		Symbol:          symbol,
+		Regime:          reading.Regime,
+		ADX:             reading.ADX,
+		Volatility:      reading.Volatility,
+		VolatilityRatio: reading.VolatilityRatio,
+		Hurst:           hurst,
+		CreatedAt:       time.Now(),
+	}
+	if err := d.db.CreateRegimeRecord(record); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+
+	from := string(previous)
+	if from == "" {
+		from = "unknown"
+	}
+	log.Printf("Regime of %s changed from %s to %s (ADX %.1f, volatility ratio %.2f, Hurst %.2f)",
+		symbol, from, reading.Regime, reading.ADX, reading.VolatilityRatio, hurst)
+}
+
+// Regime returns a symbol's current regime
+func (d *Detector) Regime(symbol string) models.Regime {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if state, exists := d.symbols[symbol]; exists {
+		return state.current
+	}
+	return ""
+}
+
+// Apply gates a symbol's signals by its regime. Signals whose style weighs 0
+// in the regime are returned as disabled; the others have their strength
+// scaled by their weight, capped at 1. Without a regime every signal is
+// kept unchanged.
+func (d *Detector) Apply(symbol string, signals []*models.TradingSignal) ([]*models.TradingSignal, []*models.TradingSignal) {
+	regime := d.Regime(symbol)
+	if regime == "" {
+		return signals, nil
+	}
+
+	var kept, disabled []*models.TradingSignal
+	for _, signal := range signals {
+		weight := d.policy.Weight(signal.Strategy, regime)
+		if weight == 0 {
+			disabled = append(disabled, signal)
+			continue
+		}
+
+		if weight != 1 {
+			signal.Strength = math.Min(1, signal.Strength*weight)
+			if signal.Indicators == nil {
+				signal.Indicators = make(map[string]float64)
+			}
+			signal.Indicators["regime_weight"] = weight
+		}
+		kept = append(kept, signal)
+	}
+	return kept, disabled
+}
@@ -0,0 +1,174 @@
+package regime
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/MunishMummadi/mock-trade-algorithm/models"
+)
+
+// Style is how a strategy expects prices to behave
+type Style string
+
+const (
+	StyleTrend         Style = "trend"          // follows moves: crossovers, breakouts, momentum
+	StyleMeanReversion Style = "mean_reversion" // fades moves: oscillators, bands, spreads
+	StyleNeutral       Style = "neutral"        // trades in every regime
+)
+
+// defaultStyles classifies the built-in strategies. Other strategies are
+// neutral unless STRATEGY_STYLES says otherwise.
+var defaultStyles = map[string]Style{
+	"sma crossover":        StyleTrend,
+	"ema crossover":        StyleTrend,
+	"macd crossover":       StyleTrend,
+	"donchian breakout":    StyleTrend,
+	"momentum rank":        StyleTrend,
+	"sector rotation":      StyleTrend,
+	"mean reversion":       StyleMeanReversion,
+	"rsi strategy":         StyleMeanReversion,
+	"pairs trading":        StyleMeanReversion,
+	"candlestick patterns": StyleMeanReversion,
+}
+
+// defaultWeights scales each style's signals per regime. A weight of 0
+// disables the style; missing entries weigh 1.
+var defaultWeights = map[models.Regime]map[Style]float64{
+	models.RegimeTrend: {
+		StyleTrend:         1,
+		StyleMeanReversion: 0,
+	},
+	models.RegimeRange: {
+		StyleTrend:         0,
+		StyleMeanReversion: 1,
+	},
+	models.RegimeHighVolatility: {
+		StyleTrend:         0.5,
+		StyleMeanReversion: 0.5,
+	},
+}
+
+// Policy maps strategies to styles and styles to per-regime weights
+type Policy struct {
+	styles  map[string]Style
+	weights map[models.Regime]map[Style]float64
+	inner   map[string]string // wrapper strategy -> strategy it wraps
+}
+
+// ParsePolicy builds the default policy with overrides applied. styles is a
+// comma separated list of "strategy=style" pairs and weights one of
+// "regime:style=weight" pairs.
+func ParsePolicy(styles, weights string) (*Policy, error) {
+	p := &Policy{
+		styles:  make(map[string]Style),
+		weights: make(map[models.Regime]map[Style]float64),
+		inner:   make(map[string]string),
+	}
+	for name, style := range defaultStyles {
+		p.styles[name] = style
+	}
+	for regime, byStyle := range defaultWeights {
+		p.weights[regime] = make(map[Style]float64)
+		for style, weight := range byStyle {
+			p.weights[regime][style] = weight
+		}
+	}
+
+	for _, part := range splitList(styles) {
+		name, value, found := strings.Cut(part, "=")
+		name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+		if !found || name == "" {
+			return nil, fmt.Errorf("expected strategy=style, got %q", part)
+		}
+		style, err := parseStyle(value)
+		if err != nil {
+			return nil, err
+		}
+		p.styles[strings.ToLower(name)] = style
+	}
+
+	for _, part := range splitList(weights) {
+		key, value, found := strings.Cut(part, "=")
+		regimeName, styleName, hasStyle := strings.Cut(key, ":")
+		if !found || !hasStyle {
+			return nil, fmt.Errorf("expected regime:style=weight, got %q", part)
+		}
+
+		regime, err := parseRegime(regimeName)
+		if err != nil {
+			return nil, err
+		}
+		style, err := parseStyle(styleName)
+		if err != nil {
+			return nil, err
+		}
+		weight, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || weight < 0 {
+			return nil, fmt.Errorf("invalid weight in %q", part)
+		}
+		p.weights[regime][style] = weight
+	}
+
+	return p, nil
+}
+
+// InheritStyle gives a wrapper strategy, such as a multi-timeframe one, the
+// style of the strategy it wraps unless it has a style of its own
+func (p *Policy) InheritStyle(wrapper, inner string) {
+	p.inner[strings.ToLower(wrapper)] = inner
+}
+
+// Style returns a strategy's style, following wrappers down to the first
+// strategy with a style. Wrappers that end up wrapping themselves are
+// neutral.
+func (p *Policy) Style(strategy string) Style {
+	name := strings.ToLower(strategy)
+	for hops := 0; hops <= len(p.inner); hops++ {
+		if style, exists := p.styles[name]; exists {
+			return style
+		}
+		inner, exists := p.inner[name]
+		if !exists {
+			break
+		}
+		name = strings.ToLower(inner)
+	}
+	return StyleNeutral
+}
+
+// Weight returns the multiplier for a strategy's signals in a regime
+func (p *Policy) Weight(strategy string, regime models.Regime) float64 {
+	if weight, exists := p.weights[regime][p.Style(strategy)]; exists {
+		return weight
+	}
+	return 1
+}
+
+func parseStyle(name string) (Style, error) {
+	switch style := Style(strings.ToLower(strings.TrimSpace(name))); style {
+	case StyleTrend, StyleMeanReversion, StyleNeutral:
+		return style, nil
+	default:
+		return "", fmt.Errorf("unknown strategy style %q (use trend, mean_reversion or neutral)", name)
+	}
+}
+
+func parseRegime(name string) (models.Regime, error) {
+	switch regime := models.Regime(strings.ToLower(strings.TrimSpace(name))); regime {
+	case models.RegimeTrend, models.RegimeRange, models.RegimeHighVolatility:
+		return regime, nil
+	default:
+		return "", fmt.Errorf("unknown regime %q (use trend, range or high_volatility)", name)
+	}
+}
+
+func splitList(value string) []string {
+	var parts []string
+	for _, part := range strings.Split(value, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return parts
+}
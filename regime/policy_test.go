@@ -0,0 +1,106 @@
+package regime
+
+import (
+	"testing"
+
+	"github.com/MunishMummadi/mock-trade-algorithm/models"
+)
+
+func TestParsePolicyOverrides(t *testing.T) {
+	p, err := ParsePolicy(" RSI Strategy = trend ,My Strategy=MEAN_REVERSION", "range:trend=0.25, high_volatility:mean_reversion=0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	styles := []struct {
+		strategy string
+		want     Style
+	}{
+		{"RSI Strategy", StyleTrend},
+		{"rsi strategy", StyleTrend},
+		{"my strategy", StyleMeanReversion},
+		{"SMA Crossover", StyleTrend},
+		{"Unknown", StyleNeutral},
+	}
+	for _, tt := range styles {
+		if got := p.Style(tt.strategy); got != tt.want {
+			t.Errorf("Style(%q) = %s, want %s", tt.strategy, got, tt.want)
+		}
+	}
+
+	weights := []struct {
+		strategy string
+		regime   models.Regime
+		want     float64
+	}{
+		{"SMA Crossover", models.RegimeRange, 0.25},
+		{"SMA Crossover", models.RegimeTrend, 1},
+		{"Mean Reversion", models.RegimeHighVolatility, 0},
+		{"Mean Reversion", models.RegimeTrend, 0},
+		{"Unknown", models.RegimeTrend, 1},
+	}
+	for _, tt := range weights {
+		if got := p.Weight(tt.strategy, tt.regime); got != tt.want {
+			t.Errorf("Weight(%q, %s) = %v, want %v", tt.strategy, tt.regime, got, tt.want)
+		}
+	}
+}
+
+func TestParsePolicyErrors(t *testing.T) {
+	tests := []struct {
+		styles, weights string
+	}{
+		{"RSI Strategy", ""},
+		{"=trend", ""},
+		{"RSI Strategy=sideways", ""},
+		{"", "trend=1"},
+		{"", "trend:momentum=1"},
+		{"", "bull:trend=1"},
+		{"", "trend:trend=x"},
+		{"", "trend:trend=-1"},
+	}
+	for _, tt := range tests {
+		if _, err := ParsePolicy(tt.styles, tt.weights); err == nil {
+			t.Errorf("ParsePolicy(%q, %q) should fail", tt.styles, tt.weights)
+		}
+	}
+
+	if _, err := ParsePolicy(" , ", ","); err != nil {
+		t.Errorf("empty entries: %v", err)
+	}
+}
+
+func TestInheritStyle(t *testing.T) {
+	p, err := ParsePolicy("RSI Strategy 1Day/1Week=neutral", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p.InheritStyle("SMA Crossover 1Day/1Week", "SMA Crossover")
+	p.InheritStyle("RSI Strategy 1Day/1Week", "RSI Strategy")
+	p.InheritStyle("Outer", "sma crossover 1day/1week")
+	p.InheritStyle("Custom 1Day/1Week", "Custom")
+	p.InheritStyle("Loop A", "Loop B")
+	p.InheritStyle("Loop B", "LOOP A")
+
+	tests := []struct {
+		strategy string
+		want     Style
+	}{
+		{"SMA Crossover 1Day/1Week", StyleTrend},
+		{"sma crossover 1DAY/1WEEK", StyleTrend},
+		{"RSI Strategy 1Day/1Week", StyleNeutral}, // its own style wins
+		{"Outer", StyleTrend},                     // wrappers of wrappers
+		{"Custom 1Day/1Week", StyleNeutral},       // inner strategy without a style
+		{"Loop A", StyleNeutral},
+	}
+	for _, tt := range tests {
+		if got := p.Style(tt.strategy); got != tt.want {
+			t.Errorf("Style(%q) = %s, want %s", tt.strategy, got, tt.want)
+		}
+	}
+
+	if got := p.Weight("SMA Crossover 1Day/1Week", models.RegimeRange); got != 0 {
+		t.Errorf("wrapped trend strategy weighs %v in a range, want 0", got)
+	}
+}
@@ -0,0 +1,320 @@
+package rules
+
+import (
+	"math"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/MunishMummadi/mock-trade-algorithm/strategies"
+)
+
+// Expr is a compiled rule
+type Expr struct {
+	source string
+	root   node
+}
+
+// Compile parses and type checks a rule
+func Compile(src string) (*Expr, error) {
+	root, err := parse(src)
+	if err != nil {
+		return nil, err
+	}
+	return &Expr{source: src, root: root}, nil
+}
+
+// String returns the rule as written
+func (x *Expr) String() string {
+	return x.source
+}
+
+// IsCondition reports whether the rule is true/false rather than a number
+func (x *Expr) IsCondition() bool {
+	return x.root.kind() == kindBool
+}
+
+// evaluator evaluates expressions over one features frame. Every value is a
+// series with one element per bar, NaN where it isn't defined yet; conditions
+// are 1 for true and 0 for false. Series are cached by their canonical form
+// so shared subexpressions are evaluated once.
+type evaluator struct {
+	features *strategies.Features
+	price    float64
+	n        int
+	cache    map[string][]float64
+}
+
+// newEvaluator evaluates over features, with price standing in for the
+// last bar's close
+func newEvaluator(features *strategies.Features, price decimal.Decimal) *evaluator {
+	return &evaluator{
+		features: features,
+		price:    price.InexactFloat64(),
+		n:        features.Len(),
+		cache:    make(map[string][]float64),
+	}
+}
+
+// last returns the expression's value on the latest bar
+func (e *evaluator) last(x *Expr) float64 {
+	if e.n == 0 {
+		return math.NaN()
+	}
+	return e.eval(x.root)[e.n-1]
+}
+
+// holds reports whether a condition is true on the latest bar. Conditions
+// that can't be evaluated yet, for lack of history, don't hold.
+func (e *evaluator) holds(x *Expr) bool {
+	return e.last(x) == 1
+}
+
+// values returns the latest value of every indicator and bar field the
+// expression refers to, keyed by its canonical form
+func (e *evaluator) values(x *Expr) map[string]float64 {
+	values := make(map[string]float64)
+	var walk func(n node)
+	walk = func(n node) {
+		switch n := n.(type) {
+		case *seriesNode:
+			values[n.String()] = e.eval(n)[e.n-1]
+		case *callNode:
+			if n.kind() == kindNumber {
+				values[n.String()] = e.eval(n)[e.n-1]
+			}
+			for _, arg := range n.args {
+				walk(arg)
+			}
+		case *unaryNode:
+			walk(n.operand)
+		case *binaryNode:
+			walk(n.left)
+			walk(n.right)
+		}
+	}
+	if e.n > 0 {
+		walk(x.root)
+	}
+	for key, v := range values {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			delete(values, key)
+		}
+	}
+	return values
+}
+
+func (e *evaluator) eval(n node) []float64 {
+	key := n.String()
+	if cached, exists := e.cache[key]; exists {
+		return cached
+	}
+
+	var out []float64
+	switch n := n.(type) {
+	case *numberNode:
+		out = make([]float64, e.n)
+		for i := range out {
+			out[i] = n.value
+		}
+
+	case *seriesNode:
+		out = e.field(n.name)
+
+	case *callNode:
+		out = n.fn.eval(e, n.args)
+
+	case *unaryNode:
+		operand := e.eval(n.operand)
+		if n.op == "not" {
+			out = e.combine(operand, operand, func(a, _ float64) float64 { return 1 - a })
+		} else {
+			out = e.combine(operand, operand, func(a, _ float64) float64 { return -a })
+		}
+
+	case *binaryNode:
+		out = e.binary(n.op, e.eval(n.left), e.eval(n.right))
+	}
+
+	e.cache[key] = out
+	return out
+}
+
+func (e *evaluator) field(name string) []float64 {
+	switch name {
+	case "open":
+		out := make([]float64, e.n)
+		for i, bar := range e.features.Bars {
+			out[i] = bar.Open
+		}
+		return out
+	case "high":
+		return e.features.Highs()
+	case "low":
+		return e.features.Lows()
+	case "volume":
+		return e.features.Volumes()
+	case "price":
+		// Closes with the last one replaced by the live price
+		out := append([]float64(nil), e.features.Closes()...)
+		if e.n > 0 && e.price > 0 {
+			out[e.n-1] = e.price
+		}
+		return out
+	}
+	return e.features.Closes()
+}
+
+func (e *evaluator) binary(op string, left, right []float64) []float64 {
+	switch op {
+	case "and":
+		return e.logical(left, right, func(a, b bool) bool { return a && b })
+	case "or":
+		return e.logical(left, right, func(a, b bool) bool { return a || b })
+	}
+
+	return e.combine(left, right, func(a, b float64) float64 {
+		switch op {
+		case "+":
+			return a + b
+		case "-":
+			return a - b
+		case "*":
+			return a * b
+		case "/":
+			if b == 0 {
+				return math.NaN()
+			}
+			return a / b
+		case "<":
+			return truth(a < b)
+		case "<=":
+			return truth(a <= b)
+		case ">":
+			return truth(a > b)
+		case ">=":
+			return truth(a >= b)
+		case "==":
+			return truth(a == b)
+		case "!=":
+			return truth(a != b)
+		}
+		return math.NaN()
+	})
+}
+
+// logical treats undefined conditions as false, so "a or b" holds as soon
+// as either side does
+func (e *evaluator) logical(left, right []float64, op func(a, b bool) bool) []float64 {
+	out := make([]float64, e.n)
+	for i := range out {
+		out[i] = truth(op(left[i] == 1, right[i] == 1))
+	}
+	return out
+}
+
+// combine applies op elementwise, propagating NaN
+func (e *evaluator) combine(left, right []float64, op func(a, b float64) float64) []float64 {
+	out := make([]float64, e.n)
+	for i := range out {
+		if math.IsNaN(left[i]) || math.IsNaN(right[i]) {
+			out[i] = math.NaN()
+			continue
+		}
+		out[i] = op(left[i], right[i])
+	}
+	return out
+}
+
+func truth(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func (e *evaluator) period(arg node) int {
+	return int(arg.(*numberNode).value)
+}
+
+func (e *evaluator) isClose(arg node) bool {
+	s, ok := arg.(*seriesNode)
+	return ok && s.name == "close"
+}
+
+// align right-aligns an indicator series to the bars, padding the warm-up
+// period with NaN
+func (e *evaluator) align(values []float64) []float64 {
+	out := make([]float64, e.n)
+	offset := e.n - len(values)
+	for i := range out {
+		if j := i - offset; j >= 0 && j < len(values) {
+			out[i] = values[j]
+		} else {
+			out[i] = math.NaN()
+		}
+	}
+	return out
+}
+
+// apply runs an indicator over an expression's series, skipping its
+// undefined leading values so they don't poison running sums
+func (e *evaluator) apply(arg node, indicator func([]float64) []float64) []float64 {
+	x := e.eval(arg)
+	start := 0
+	for start < len(x) && math.IsNaN(x[start]) {
+		start++
+	}
+	return e.align(indicator(x[start:]))
+}
+
+// window applies reduce to every trailing window of n values
+func (e *evaluator) window(arg node, n int, reduce func([]float64) float64) []float64 {
+	x := e.eval(arg)
+	out := make([]float64, e.n)
+	for i := range out {
+		out[i] = math.NaN()
+		if i+1 < n {
+			continue
+		}
+		w := x[i+1-n : i+1]
+		if !anyNaN(w) {
+			out[i] = reduce(w)
+		}
+	}
+	return out
+}
+
+// lag shifts a series k bars into the future
+func (e *evaluator) lag(x []float64, k int) []float64 {
+	out := make([]float64, e.n)
+	for i := range out {
+		if i >= k {
+			out[i] = x[i-k]
+		} else {
+			out[i] = math.NaN()
+		}
+	}
+	return out
+}
+
+func (e *evaluator) cross(args []node, crossed func(a, b, prevA, prevB float64) bool) []float64 {
+	a, b := e.eval(args[0]), e.eval(args[1])
+	out := make([]float64, e.n)
+	for i := range out {
+		if i == 0 || anyNaN([]float64{a[i], b[i], a[i-1], b[i-1]}) {
+			out[i] = math.NaN()
+			continue
+		}
+		out[i] = truth(crossed(a[i], b[i], a[i-1], b[i-1]))
+	}
+	return out
+}
+
+func anyNaN(values []float64) bool {
+	for _, v := range values {
+		if math.IsNaN(v) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,343 @@
+package rules
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/MunishMummadi/mock-trade-algorithm/indicators"
+)
+
+type paramKind int
+
+const (
+	paramSeries paramKind = iota // any number expression
+	paramPeriod                  // a positive whole number constant
+	paramNumber                  // any number constant
+)
+
+type param struct {
+	name string
+	kind paramKind
+}
+
+func (p param) check(arg node) error {
+	if arg.kind() != kindNumber {
+		return fmt.Errorf("%s must be a number, not a condition", p.name)
+	}
+	if p.kind == paramSeries {
+		return nil
+	}
+
+	number, ok := arg.(*numberNode)
+	if !ok {
+		return fmt.Errorf("%s must be a constant", p.name)
+	}
+	if p.kind == paramPeriod && (number.value < 1 || number.value != math.Trunc(number.value)) {
+		return fmt.Errorf("%s must be a positive whole number, got %s", p.name, number)
+	}
+	return nil
+}
+
+// function is a built-in. eval receives the call's arguments and returns a
+// series with one value per bar.
+type function struct {
+	params []param
+	result valueKind
+	doc    string
+	eval   func(e *evaluator, args []node) []float64
+}
+
+func (f *function) signature() string {
+	names := make([]string, len(f.params))
+	for i, p := range f.params {
+		names[i] = p.name
+	}
+	return strings.Join(names, ", ")
+}
+
+var (
+	series = param{"series", paramSeries}
+	period = param{"period", paramPeriod}
+)
+
+// functions is the built-in library. Indicators over close use the
+// memoized Features series, so rules share computations with the Go
+// strategies; over any other series they are computed directly.
+var functions = map[string]*function{
+	"sma": {
+		params: []param{series, period},
+		doc:    "simple moving average",
+		eval: func(e *evaluator, args []node) []float64 {
+			n := e.period(args[1])
+			if e.isClose(args[0]) {
+				return e.align(e.features.SMA(n))
+			}
+			return e.apply(args[0], func(x []float64) []float64 { return indicators.SMA(x, n) })
+		},
+	},
+	"ema": {
+		params: []param{series, period},
+		doc:    "exponential moving average",
+		eval: func(e *evaluator, args []node) []float64 {
+			n := e.period(args[1])
+			if e.isClose(args[0]) {
+				return e.align(e.features.EMA(n))
+			}
+			return e.apply(args[0], func(x []float64) []float64 { return indicators.EMA(x, n) })
+		},
+	},
+	"rsi": {
+		params: []param{series, period},
+		doc:    "Wilder's relative strength index, 0 to 100",
+		eval: func(e *evaluator, args []node) []float64 {
+			n := e.period(args[1])
+			if e.isClose(args[0]) {
+				return e.align(e.features.RSI(n))
+			}
+			return e.apply(args[0], func(x []float64) []float64 { return indicators.RSI(x, n) })
+		},
+	},
+	"stddev": {
+		params: []param{series, period},
+		doc:    "population standard deviation over the window",
+		eval: func(e *evaluator, args []node) []float64 {
+			n := e.period(args[1])
+			return e.window(args[0], n, func(w []float64) float64 {
+				mean := 0.0
+				for _, v := range w {
+					mean += v
+				}
+				mean /= float64(len(w))
+				sum := 0.0
+				for _, v := range w {
+					sum += (v - mean) * (v - mean)
+				}
+				return math.Sqrt(sum / float64(len(w)))
+			})
+		},
+	},
+	"highest": {
+		params: []param{series, period},
+		doc:    "highest value over the window",
+		eval: func(e *evaluator, args []node) []float64 {
+			return e.window(args[0], e.period(args[1]), func(w []float64) float64 {
+				highest := math.Inf(-1)
+				for _, v := range w {
+					highest = math.Max(highest, v)
+				}
+				return highest
+			})
+		},
+	},
+	"lowest": {
+		params: []param{series, period},
+		doc:    "lowest value over the window",
+		eval: func(e *evaluator, args []node) []float64 {
+			return e.window(args[0], e.period(args[1]), func(w []float64) float64 {
+				lowest := math.Inf(1)
+				for _, v := range w {
+					lowest = math.Min(lowest, v)
+				}
+				return lowest
+			})
+		},
+	},
+	"prev": {
+		params: []param{series, period},
+		doc:    "value the given number of bars ago",
+		eval: func(e *evaluator, args []node) []float64 {
+			return e.lag(e.eval(args[0]), e.period(args[1]))
+		},
+	},
+	"change": {
+		params: []param{series, period},
+		doc:    "difference from the value the given number of bars ago",
+		eval: func(e *evaluator, args []node) []float64 {
+			x := e.eval(args[0])
+			previous := e.lag(x, e.period(args[1]))
+			return e.combine(x, previous, func(a, b float64) float64 { return a - b })
+		},
+	},
+	"roc": {
+		params: []param{series, period},
+		doc:    "percent change from the value the given number of bars ago",
+		eval: func(e *evaluator, args []node) []float64 {
+			x := e.eval(args[0])
+			previous := e.lag(x, e.period(args[1]))
+			return e.combine(x, previous, func(a, b float64) float64 { return 100 * (a/b - 1) })
+		},
+	},
+
+	"bb_upper":  bollinger(0),
+	"bb_middle": bollinger(1),
+	"bb_lower":  bollinger(2),
+
+	"macd":        macd(0),
+	"macd_signal": macd(1),
+	"macd_hist":   macd(2),
+
+	"atr": {
+		params: []param{period},
+		doc:    "Wilder's average true range",
+		eval: func(e *evaluator, args []node) []float64 {
+			return e.align(e.features.ATR(e.period(args[0])))
+		},
+	},
+	"adx":      adx(0, "average directional index"),
+	"plus_di":  adx(1, "positive directional indicator"),
+	"minus_di": adx(2, "negative directional indicator"),
+	"cci": {
+		params: []param{period},
+		doc:    "commodity channel index",
+		eval: func(e *evaluator, args []node) []float64 {
+			return e.align(indicators.CCI(e.features.Bars, e.period(args[0])))
+		},
+	},
+	"williams_r": {
+		params: []param{period},
+		doc:    "Williams %R, -100 to 0",
+		eval: func(e *evaluator, args []node) []float64 {
+			return e.align(indicators.WilliamsR(e.features.Bars, e.period(args[0])))
+		},
+	},
+	"stoch_k": {
+		params: []param{{"k_period", paramPeriod}, {"d_period", paramPeriod}},
+		doc:    "stochastic %K, 0 to 100",
+		eval: func(e *evaluator, args []node) []float64 {
+			k, _ := indicators.Stochastic(e.features.Bars, e.period(args[0]), e.period(args[1]))
+			return e.align(k)
+		},
+	},
+	"stoch_d": {
+		params: []param{{"k_period", paramPeriod}, {"d_period", paramPeriod}},
+		doc:    "stochastic %D, the moving average of %K",
+		eval: func(e *evaluator, args []node) []float64 {
+			_, d := indicators.Stochastic(e.features.Bars, e.period(args[0]), e.period(args[1]))
+			return e.align(d)
+		},
+	},
+	"obv": {
+		doc: "on-balance volume",
+		eval: func(e *evaluator, args []node) []float64 {
+			return e.align(indicators.OBV(e.features.Bars))
+		},
+	},
+	"vwap": {
+		doc: "session volume weighted average price",
+		eval: func(e *evaluator, args []node) []float64 {
+			return e.align(indicators.VWAP(e.features.Bars))
+		},
+	},
+
+	"crosses_above": {
+		params: []param{{"a", paramSeries}, {"b", paramSeries}},
+		result: kindBool,
+		doc:    "a moved from at or below b on the previous bar to above it",
+		eval: func(e *evaluator, args []node) []float64 {
+			return e.cross(args, func(a, b, prevA, prevB float64) bool { return prevA <= prevB && a > b })
+		},
+	},
+	"crosses_below": {
+		params: []param{{"a", paramSeries}, {"b", paramSeries}},
+		result: kindBool,
+		doc:    "a moved from at or above b on the previous bar to below it",
+		eval: func(e *evaluator, args []node) []float64 {
+			return e.cross(args, func(a, b, prevA, prevB float64) bool { return prevA >= prevB && a < b })
+		},
+	},
+
+	"abs": {
+		params: []param{series},
+		doc:    "absolute value",
+		eval: func(e *evaluator, args []node) []float64 {
+			x := e.eval(args[0])
+			return e.combine(x, x, func(a, _ float64) float64 { return math.Abs(a) })
+		},
+	},
+	"min": {
+		params: []param{{"a", paramSeries}, {"b", paramSeries}},
+		doc:    "smaller of a and b",
+		eval: func(e *evaluator, args []node) []float64 {
+			return e.combine(e.eval(args[0]), e.eval(args[1]), math.Min)
+		},
+	},
+	"max": {
+		params: []param{{"a", paramSeries}, {"b", paramSeries}},
+		doc:    "larger of a and b",
+		eval: func(e *evaluator, args []node) []float64 {
+			return e.combine(e.eval(args[0]), e.eval(args[1]), math.Max)
+		},
+	},
+}
+
+// bollinger returns one band of the Bollinger Bands of a series
+func bollinger(band int) *function {
+	names := []string{"upper", "middle", "lower"}
+	return &function{
+		params: []param{series, period, {"std_devs", paramNumber}},
+		doc:    fmt.Sprintf("%s Bollinger band", names[band]),
+		eval: func(e *evaluator, args []node) []float64 {
+			n := e.period(args[1])
+			k := args[2].(*numberNode).value
+			if e.isClose(args[0]) {
+				upper, middle, lower := e.features.BollingerBands(n, k)
+				return e.align([][]float64{upper, middle, lower}[band])
+			}
+			return e.apply(args[0], func(x []float64) []float64 {
+				upper, middle, lower := indicators.BollingerBands(x, n, k)
+				return [][]float64{upper, middle, lower}[band]
+			})
+		},
+	}
+}
+
+// macd returns the MACD line, signal line or histogram of a series
+func macd(line int) *function {
+	names := []string{"MACD line", "MACD signal line", "MACD histogram"}
+	return &function{
+		params: []param{series, {"fast", paramPeriod}, {"slow", paramPeriod}, {"signal", paramPeriod}},
+		doc:    names[line],
+		eval: func(e *evaluator, args []node) []float64 {
+			fast, slow, signal := e.period(args[1]), e.period(args[2]), e.period(args[3])
+			if e.isClose(args[0]) {
+				macdLine, signalLine, histogram := e.features.MACD(fast, slow, signal)
+				return e.align([][]float64{macdLine, signalLine, histogram}[line])
+			}
+			return e.apply(args[0], func(x []float64) []float64 {
+				macdLine, signalLine, histogram := indicators.MACD(x, fast, slow, signal)
+				return [][]float64{macdLine, signalLine, histogram}[line]
+			})
+		},
+	}
+}
+
+// adx returns ADX, +DI or -DI
+func adx(output int, doc string) *function {
+	return &function{
+		params: []param{period},
+		doc:    doc,
+		eval: func(e *evaluator, args []node) []float64 {
+			adx, plusDI, minusDI := indicators.ADX(e.features.Bars, e.period(args[0]))
+			return e.align([][]float64{adx, plusDI, minusDI}[output])
+		},
+	}
+}
+
+// Functions lists the built-in functions with their parameters, for help
+// output
+func Functions() []string {
+	names := make([]string, 0, len(functions))
+	for name := range functions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	help := make([]string, len(names))
+	for i, name := range names {
+		fn := functions[name]
+		help[i] = fmt.Sprintf("%s(%s): %s", name, fn.signature(), fn.doc)
+	}
+	return help
+}
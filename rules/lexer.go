@@ -0,0 +1,122 @@
+// Package rules implements a small expression language for trading rules
+// such as
+//
+//	crosses_above(sma(close,20), sma(close,50)) and rsi(close,14) < 70
+//
+// Rules are compiled once, checked for unknown functions and wrong argument
+// counts up front, and evaluated against a strategies.Features frame.
+package rules
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenNumber
+	tokenIdent
+	tokenOperator
+	tokenLParen
+	tokenRParen
+	tokenComma
+)
+
+type token struct {
+	kind   tokenKind
+	text   string
+	number float64
+	pos    int // 1-based column in the source
+}
+
+func (t token) String() string {
+	if t.kind == tokenEOF {
+		return "end of rule"
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+// operators are matched longest first
+var operators = []string{"<=", ">=", "==", "!=", "&&", "||", "<", ">", "+", "-", "*", "/", "!"}
+
+// lex splits a rule into tokens. The keywords and, or and not are returned
+// as operators, with &&, || and ! as aliases.
+func lex(src string) ([]token, error) {
+	var tokens []token
+
+	for i := 0; i < len(src); {
+		c := rune(src[i])
+		switch {
+		case unicode.IsSpace(c):
+			i++
+
+		case c == '(':
+			tokens = append(tokens, token{kind: tokenLParen, text: "(", pos: i + 1})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{kind: tokenRParen, text: ")", pos: i + 1})
+			i++
+		case c == ',':
+			tokens = append(tokens, token{kind: tokenComma, text: ",", pos: i + 1})
+			i++
+
+		case unicode.IsDigit(c) || c == '.':
+			start := i
+			for i < len(src) && (unicode.IsDigit(rune(src[i])) || src[i] == '.') {
+				i++
+			}
+			value, err := strconv.ParseFloat(src[start:i], 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid number %q at column %d", src[start:i], start+1)
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: src[start:i], number: value, pos: start + 1})
+
+		case unicode.IsLetter(c) || c == '_':
+			start := i
+			for i < len(src) && (unicode.IsLetter(rune(src[i])) || unicode.IsDigit(rune(src[i])) || src[i] == '_') {
+				i++
+			}
+			word := strings.ToLower(src[start:i])
+			switch word {
+			case "and":
+				tokens = append(tokens, token{kind: tokenOperator, text: "and", pos: start + 1})
+			case "or":
+				tokens = append(tokens, token{kind: tokenOperator, text: "or", pos: start + 1})
+			case "not":
+				tokens = append(tokens, token{kind: tokenOperator, text: "not", pos: start + 1})
+			default:
+				tokens = append(tokens, token{kind: tokenIdent, text: word, pos: start + 1})
+			}
+
+		default:
+			matched := ""
+			for _, op := range operators {
+				if strings.HasPrefix(src[i:], op) {
+					matched = op
+					break
+				}
+			}
+			if matched == "" {
+				return nil, fmt.Errorf("unexpected character %q at column %d", c, i+1)
+			}
+
+			text := matched
+			switch matched {
+			case "&&":
+				text = "and"
+			case "||":
+				text = "or"
+			case "!":
+				text = "not"
+			}
+			tokens = append(tokens, token{kind: tokenOperator, text: text, pos: i + 1})
+			i += len(matched)
+		}
+	}
+
+	return append(tokens, token{kind: tokenEOF, pos: len(src) + 1}), nil
+}
@@ -0,0 +1,372 @@
+package rules
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// valueKind is the static type of an expression
+type valueKind int
+
+const (
+	kindNumber valueKind = iota
+	kindBool
+)
+
+func (k valueKind) String() string {
+	if k == kindBool {
+		return "condition"
+	}
+	return "number"
+}
+
+// node is a compiled expression. String renders it canonically and doubles
+// as the key under which its series is cached during evaluation.
+type node interface {
+	kind() valueKind
+	String() string
+}
+
+type numberNode struct {
+	value float64
+}
+
+func (n *numberNode) kind() valueKind { return kindNumber }
+func (n *numberNode) String() string  { return strconv.FormatFloat(n.value, 'g', -1, 64) }
+
+// seriesNode is one of the bar fields: open, high, low, close, volume or price
+type seriesNode struct {
+	name string
+}
+
+func (n *seriesNode) kind() valueKind { return kindNumber }
+func (n *seriesNode) String() string  { return n.name }
+
+type callNode struct {
+	name string
+	fn   *function
+	args []node
+}
+
+func (n *callNode) kind() valueKind { return n.fn.result }
+func (n *callNode) String() string {
+	args := make([]string, len(n.args))
+	for i, arg := range n.args {
+		args[i] = arg.String()
+		if _, binary := arg.(*binaryNode); binary {
+			// The call's parentheses already group the argument
+			args[i] = args[i][1 : len(args[i])-1]
+		}
+	}
+	return fmt.Sprintf("%s(%s)", n.name, strings.Join(args, ","))
+}
+
+type unaryNode struct {
+	op      string
+	operand node
+}
+
+func (n *unaryNode) kind() valueKind { return n.operand.kind() }
+func (n *unaryNode) String() string {
+	if n.op == "not" {
+		return "not " + n.operand.String()
+	}
+	return n.op + n.operand.String()
+}
+
+type binaryNode struct {
+	op          string
+	left, right node
+}
+
+func (n *binaryNode) kind() valueKind {
+	switch n.op {
+	case "+", "-", "*", "/":
+		return kindNumber
+	}
+	return kindBool
+}
+
+func (n *binaryNode) String() string {
+	return fmt.Sprintf("(%s %s %s)", n.left.String(), n.op, n.right.String())
+}
+
+// seriesNames are the identifiers usable without a call
+var seriesNames = map[string]bool{
+	"open":   true,
+	"high":   true,
+	"low":    true,
+	"close":  true,
+	"volume": true,
+	"price":  true,
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) isOperator(ops ...string) bool {
+	t := p.peek()
+	if t.kind != tokenOperator {
+		return false
+	}
+	for _, op := range ops {
+		if t.text == op {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *parser) expect(kind tokenKind, what string) (token, error) {
+	t := p.next()
+	if t.kind != kind {
+		return t, fmt.Errorf("expected %s at column %d, found %s", what, t.pos, t)
+	}
+	return t, nil
+}
+
+// parse parses a complete rule
+func parse(src string) (node, error) {
+	tokens, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	n, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, fmt.Errorf("unexpected %s at column %d", t, t.pos)
+	}
+	return n, nil
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOperator("or") {
+		t := p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		if left, err = logical(t, left, right); err != nil {
+			return nil, err
+		}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOperator("and") {
+		t := p.next()
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		if left, err = logical(t, left, right); err != nil {
+			return nil, err
+		}
+	}
+	return left, nil
+}
+
+func logical(t token, left, right node) (node, error) {
+	if left.kind() != kindBool || right.kind() != kindBool {
+		return nil, fmt.Errorf("%s at column %d needs conditions on both sides", t.text, t.pos)
+	}
+	return &binaryNode{op: t.text, left: left, right: right}, nil
+}
+
+func (p *parser) parseNot() (node, error) {
+	if p.isOperator("not") {
+		t := p.next()
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		if operand.kind() != kindBool {
+			return nil, fmt.Errorf("not at column %d needs a condition", t.pos)
+		}
+		return &unaryNode{op: "not", operand: operand}, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (node, error) {
+	left, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+	if !p.isOperator("<", "<=", ">", ">=", "==", "!=") {
+		return left, nil
+	}
+
+	t := p.next()
+	right, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+	if left.kind() != kindNumber || right.kind() != kindNumber {
+		return nil, fmt.Errorf("%s at column %d compares numbers, not conditions", t.text, t.pos)
+	}
+	if p.isOperator("<", "<=", ">", ">=", "==", "!=") {
+		next := p.peek()
+		return nil, fmt.Errorf("chained comparison at column %d, combine comparisons with and", next.pos)
+	}
+	return &binaryNode{op: t.text, left: left, right: right}, nil
+}
+
+func (p *parser) parseAdditive() (node, error) {
+	left, err := p.parseMultiplicative()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOperator("+", "-") {
+		t := p.next()
+		right, err := p.parseMultiplicative()
+		if err != nil {
+			return nil, err
+		}
+		if left, err = arithmetic(t, left, right); err != nil {
+			return nil, err
+		}
+	}
+	return left, nil
+}
+
+func (p *parser) parseMultiplicative() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOperator("*", "/") {
+		t := p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		if left, err = arithmetic(t, left, right); err != nil {
+			return nil, err
+		}
+	}
+	return left, nil
+}
+
+func arithmetic(t token, left, right node) (node, error) {
+	if left.kind() != kindNumber || right.kind() != kindNumber {
+		return nil, fmt.Errorf("%s at column %d needs numbers on both sides", t.text, t.pos)
+	}
+	return &binaryNode{op: t.text, left: left, right: right}, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if p.isOperator("-") {
+		t := p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		if operand.kind() != kindNumber {
+			return nil, fmt.Errorf("- at column %d needs a number", t.pos)
+		}
+		if number, ok := operand.(*numberNode); ok {
+			return &numberNode{value: -number.value}, nil
+		}
+		return &unaryNode{op: "-", operand: operand}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenNumber:
+		return &numberNode{value: t.number}, nil
+
+	case tokenLParen:
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(tokenRParen, ")"); err != nil {
+			return nil, err
+		}
+		return inner, nil
+
+	case tokenIdent:
+		if p.peek().kind == tokenLParen {
+			return p.parseCall(t)
+		}
+		if !seriesNames[t.text] {
+			if _, exists := functions[t.text]; exists {
+				return nil, fmt.Errorf("%s at column %d is a function, call it as %s(...)", t.text, t.pos, t.text)
+			}
+			return nil, fmt.Errorf("unknown name %q at column %d", t.text, t.pos)
+		}
+		return &seriesNode{name: t.text}, nil
+	}
+
+	return nil, fmt.Errorf("unexpected %s at column %d", t, t.pos)
+}
+
+func (p *parser) parseCall(name token) (node, error) {
+	fn, exists := functions[name.text]
+	if !exists {
+		return nil, fmt.Errorf("unknown function %q at column %d", name.text, name.pos)
+	}
+	p.next() // (
+
+	var args []node
+	if p.peek().kind != tokenRParen {
+		for {
+			arg, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+			if p.peek().kind != tokenComma {
+				break
+			}
+			p.next()
+		}
+	}
+	if _, err := p.expect(tokenRParen, ") or ,"); err != nil {
+		return nil, err
+	}
+
+	if len(args) != len(fn.params) {
+		return nil, fmt.Errorf("%s at column %d takes %d arguments (%s), got %d",
+			name.text, name.pos, len(fn.params), fn.signature(), len(args))
+	}
+	for i, param := range fn.params {
+		if err := param.check(args[i]); err != nil {
+			return nil, fmt.Errorf("argument %d of %s at column %d: %w", i+1, name.text, name.pos, err)
+		}
+	}
+
+	return &callNode{name: name.text, fn: fn, args: args}, nil
+}
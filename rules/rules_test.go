@@ -0,0 +1,334 @@
+package rules
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/MunishMummadi/mock-trade-algorithm/alpaca"
+	"github.com/MunishMummadi/mock-trade-algorithm/strategies"
+)
+
+// nan marks bars where a series isn't defined yet
+var nan = math.NaN()
+
+// closingBars returns daily bars with the given closes, each opening at the
+// previous close
+func closingBars(closes ...float64) []alpaca.MockBar {
+	start := time.Date(2026, 1, 5, 16, 0, 0, 0, time.UTC)
+	bars := make([]alpaca.MockBar, len(closes))
+	prev := closes[0]
+	for i, close := range closes {
+		bars[i] = alpaca.MockBar{
+			Timestamp: start.AddDate(0, 0, i),
+			Open:      prev,
+			High:      math.Max(prev, close),
+			Low:       math.Min(prev, close),
+			Close:     close,
+			Volume:    1000,
+		}
+		prev = close
+	}
+	return bars
+}
+
+// evalSeries compiles src and evaluates it on every bar
+func evalSeries(t *testing.T, src string, bars []alpaca.MockBar, price float64) []float64 {
+	t.Helper()
+	x, err := Compile(src)
+	if err != nil {
+		t.Fatalf("Compile(%q): %v", src, err)
+	}
+	e := newEvaluator(strategies.NewFeatures("TEST", bars), decimal.NewFromFloat(price))
+	return e.eval(x.root)
+}
+
+func assertSeries(t *testing.T, name string, got, want []float64) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("%s: got %d values, want %d", name, len(got), len(want))
+	}
+	for i := range want {
+		if math.IsNaN(want[i]) != math.IsNaN(got[i]) || (!math.IsNaN(want[i]) && math.Abs(got[i]-want[i]) > 1e-9) {
+			t.Fatalf("%s[%d]: got %v, want %v (series %v)", name, i, got[i], want[i], got)
+		}
+	}
+}
+
+func TestParsePrecedence(t *testing.T) {
+	tests := []struct {
+		src  string
+		want string
+	}{
+		{"1 + 2 * 3 > 4", "((1 + (2 * 3)) > 4)"},
+		{"(1 + 2) * 3 > 4", "(((1 + 2) * 3) > 4)"},
+		{"10 - 4 - 3 > close", "(((10 - 4) - 3) > close)"},
+		{"close / 2 / 4 < 1", "(((close / 2) / 4) < 1)"},
+		{"-close * 2 < 0", "((-close * 2) < 0)"},
+		{"close - -1 > 0", "((close - -1) > 0)"},
+		{"close > 1 or close < 2 and volume > 3", "((close > 1) or ((close < 2) and (volume > 3)))"},
+		{"(close > 1 or close < 2) and volume > 3", "(((close > 1) or (close < 2)) and (volume > 3))"},
+		{"not close > 1 and volume > 2", "(not (close > 1) and (volume > 2))"},
+		{"not not close > 1", "not not (close > 1)"},
+		{"sma(close + 1, 5) > close", "(sma(close + 1,5) > close)"},
+		{"crosses_above(sma(close,20), sma(close,50)) and rsi(close,14) < 70",
+			"(crosses_above(sma(close,20),sma(close,50)) and (rsi(close,14) < 70))"},
+	}
+
+	for _, tt := range tests {
+		x, err := Compile(tt.src)
+		if err != nil {
+			t.Errorf("Compile(%q): %v", tt.src, err)
+			continue
+		}
+		if got := x.root.String(); got != tt.want {
+			t.Errorf("Compile(%q) = %s, want %s", tt.src, got, tt.want)
+		}
+		if !x.IsCondition() {
+			t.Errorf("Compile(%q) is not a condition", tt.src)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		src  string
+		want string
+	}{
+		// Chained comparisons
+		{"close > 1 > 0", "chained comparison at column 11"},
+		{"1 < close <= 2", "chained comparison at column 11"},
+		{"sma(close,5) == close != 1", "chained comparison"},
+
+		// Type errors
+		{"close and volume > 1", "and at column 7 needs conditions on both sides"},
+		{"close > 1 or 2", "or at column 11 needs conditions on both sides"},
+		{"not close", "not at column 1 needs a condition"},
+		{"(close > 1) + 1 > 0", "+ at column 13 needs numbers on both sides"},
+		{"-(close > 1)", "- at column 1 needs a number"},
+		{"(close > 1) > (volume > 1)", "compares numbers, not conditions"},
+
+		// Constant arguments
+		{"sma(close, close) > 0", "argument 2 of sma at column 1: period must be a constant"},
+		{"sma(close, 2 + 3) > 0", "period must be a constant"},
+		{"sma(close, 2.5) > 0", "period must be a positive whole number, got 2.5"},
+		{"sma(close, 0) > 0", "period must be a positive whole number, got 0"},
+		{"sma(close, -3) > 0", "period must be a positive whole number, got -3"},
+		{"atr(close) > 0", "argument 1 of atr at column 1: period must be a constant"},
+		{"stoch_k(14, volume) > 0", "d_period must be a constant"},
+		{"bb_upper(close, 20, close) > 0", "must be a constant"},
+		{"sma(close > 1, 5) > 0", "series must be a number, not a condition"},
+
+		// Calls and names
+		{"sma(close) > 0", "sma at column 1 takes 2 arguments (series, period), got 1"},
+		{"obv(1) > 0", "obv at column 1 takes 0 arguments"},
+		{"foo(close) > 0", `unknown function "foo" at column 1`},
+		{"sma > 0", "sma at column 1 is a function"},
+		{"closes > 0", `unknown name "closes" at column 1`},
+		{"(close > 1", "expected )"},
+		{"close > 1)", "unexpected"},
+	}
+
+	for _, tt := range tests {
+		_, err := Compile(tt.src)
+		if err == nil {
+			t.Errorf("Compile(%q) succeeded, want error containing %q", tt.src, tt.want)
+			continue
+		}
+		if !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Compile(%q) = %q, want error containing %q", tt.src, err, tt.want)
+		}
+	}
+}
+
+func TestCompileRuleChecksKind(t *testing.T) {
+	if _, err := compileRule("close + 1", true); err == nil || !strings.Contains(err.Error(), "must be a condition") {
+		t.Errorf("number as a buy rule: got %v", err)
+	}
+	if _, err := compileRule("close > 1", false); err == nil || !strings.Contains(err.Error(), "must be a number") {
+		t.Errorf("condition as a strength: got %v", err)
+	}
+	if x, err := compileRule("  ", true); x != nil || err != nil {
+		t.Errorf("blank rule: got %v, %v", x, err)
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	bars := closingBars(10, 12, 14, 13, 11, 15)
+
+	tests := []struct {
+		name  string
+		src   string
+		price float64
+		want  []float64
+	}{
+		{"arithmetic", "close * 2 - 1", 0, []float64{19, 23, 27, 25, 21, 29}},
+		{"division by zero", "close / (close - 12)", 0, []float64{-5, nan, 7, 13, -11, 5}},
+		{"price replaces the last close", "price", 16, []float64{10, 12, 14, 13, 11, 16}},
+		{"comparison", "close >= 13", 0, []float64{0, 0, 1, 1, 0, 1}},
+		{"sma warm-up", "sma(close, 3)", 0, []float64{nan, nan, 12, 13, 38.0 / 3, 13}},
+		{"comparison propagates warm-up", "close > sma(close, 3)", 0, []float64{nan, nan, 1, 0, 0, 1}},
+		{"not propagates warm-up", "not close > sma(close, 3)", 0, []float64{nan, nan, 0, 1, 1, 0}},
+		{"prev", "prev(close, 2)", 0, []float64{nan, nan, 10, 12, 14, 13}},
+		{"change", "change(close, 1)", 0, []float64{nan, 2, 2, -1, -2, 4}},
+		{"highest", "highest(close, 3)", 0, []float64{nan, nan, 14, 14, 14, 15}},
+		{"indicator over an expression skips its warm-up", "sma(prev(close, 1), 2)", 0, []float64{nan, nan, 11, 13, 13.5, 12}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertSeries(t, tt.src, evalSeries(t, tt.src, bars, tt.price), tt.want)
+		})
+	}
+}
+
+// TestLogicalWarmUp checks that and/or treat an undefined side as false
+// rather than propagating NaN, so "a or b" holds as soon as either does
+func TestLogicalWarmUp(t *testing.T) {
+	bars := closingBars(10, 12, 14, 13, 11, 15)
+
+	tests := []struct {
+		src  string
+		want []float64
+	}{
+		{"sma(close, 3) > 0 or close > 11", []float64{0, 1, 1, 1, 1, 1}},
+		{"close > 11 or sma(close, 3) > 0", []float64{0, 1, 1, 1, 1, 1}},
+		{"sma(close, 3) > 0 and close > 11", []float64{0, 0, 1, 1, 0, 1}},
+		{"close > 0 and sma(close, 3) > 0", []float64{0, 0, 1, 1, 1, 1}},
+		{"sma(close, 3) > 0 or sma(close, 4) > 0", []float64{0, 0, 1, 1, 1, 1}},
+		{"not (sma(close, 3) > 0 or close > 11)", []float64{1, 0, 0, 0, 0, 0}},
+	}
+
+	for _, tt := range tests {
+		assertSeries(t, tt.src, evalSeries(t, tt.src, bars, 0), tt.want)
+	}
+}
+
+func TestCrosses(t *testing.T) {
+	// Touching the level is not a cross; leaving it from there is
+	bars := closingBars(1, 2, 3, 2, 1, 2, 3)
+
+	tests := []struct {
+		src  string
+		want []float64
+	}{
+		{"crosses_above(close, 2)", []float64{nan, 0, 1, 0, 0, 0, 1}},
+		{"crosses_below(close, 2)", []float64{nan, 0, 0, 0, 1, 0, 0}},
+		{"crosses_above(2, close)", []float64{nan, 0, 0, 0, 1, 0, 0}},
+		// Both sides must be defined on this bar and the one before
+		{"crosses_above(close, sma(close, 3))", []float64{nan, nan, nan, 0, 0, 1, 0}},
+		{"crosses_below(close, sma(close, 3))", []float64{nan, nan, nan, 1, 0, 0, 0}},
+	}
+
+	for _, tt := range tests {
+		assertSeries(t, tt.src, evalSeries(t, tt.src, bars, 0), tt.want)
+	}
+}
+
+func TestStrategyWarmUpDoesNotSignal(t *testing.T) {
+	s, err := NewStrategy(Definition{
+		Name: "Test",
+		Buy:  "close > sma(close, 5)",
+		Sell: "close < sma(close, 5) or close < 0",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	price := decimal.NewFromInt(20)
+	if signal := s.AnalyzeFeatures(strategies.NewFeatures("TEST", closingBars(10, 12, 20)), price); signal != nil {
+		t.Fatalf("signalled %s during the warm-up", signal.Signal)
+	}
+
+	signal := s.AnalyzeFeatures(strategies.NewFeatures("TEST", closingBars(10, 11, 12, 13, 14, 20)), price)
+	if signal == nil || signal.Signal != "BUY" || signal.Strength != DefaultStrength {
+		t.Fatalf("got %+v, want a BUY at the default strength", signal)
+	}
+	if got := signal.Indicators["sma(close,5)"]; math.Abs(got-14) > 1e-9 {
+		t.Fatalf("sma(close,5) = %v, want 14", got)
+	}
+}
+
+func TestLoadDirExamples(t *testing.T) {
+	loaded, err := LoadDir(filepath.Join("..", "config", "rules"))
+	if err != nil {
+		t.Fatalf("LoadDir: %v", err)
+	}
+	if len(loaded) == 0 {
+		t.Fatal("no example rule strategies were loaded")
+	}
+
+	// Every example must evaluate over a realistic history without panicking
+	closes := make([]float64, 120)
+	for i := range closes {
+		closes[i] = 100 + 10*math.Sin(float64(i)/8)
+	}
+	features := strategies.NewFeatures("TEST", closingBars(closes...))
+	for _, s := range loaded {
+		if s.GetName() == "" || s.buy == nil && s.sell == nil {
+			t.Errorf("%q loaded without a name or rules", s.GetName())
+		}
+		s.AnalyzeFeatures(features, decimal.NewFromFloat(closes[len(closes)-1]))
+	}
+}
+
+func TestLoadDirErrors(t *testing.T) {
+	write := func(t *testing.T, dir, name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  string
+	}{
+		{
+			name: "duplicate names",
+			files: map[string]string{
+				"a.json": `{"name": "Breakout", "buy": "close > highest(close, 20)"}`,
+				"b.json": `{"name": "breakout", "sell": "close < lowest(close, 20)"}`,
+			},
+			want: `rule strategy "breakout" is defined in both`,
+		},
+		{
+			name:  "invalid rule",
+			files: map[string]string{"a.json": `{"name": "Bad", "buy": "close > 1 > 0"}`},
+			want:  `invalid buy rule of "Bad": chained comparison`,
+		},
+		{
+			name:  "unknown field",
+			files: map[string]string{"a.json": `{"name": "Typo", "buy": "close > 1", "stregth": "1"}`},
+			want:  "unknown field",
+		},
+		{
+			name:  "no rules",
+			files: map[string]string{"a.json": `{"name": "Empty"}`},
+			want:  "neither a buy nor a sell rule",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tt.files {
+				write(t, dir, name, content)
+			}
+			_, err := LoadDir(dir)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("LoadDir: got %v, want error containing %q", err, tt.want)
+			}
+		})
+	}
+
+	if _, err := LoadDir(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Fatal("LoadDir of a missing directory succeeded")
+	}
+}
@@ -0,0 +1,206 @@
+package rules
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/MunishMummadi/mock-trade-algorithm/alpaca"
+	"github.com/MunishMummadi/mock-trade-algorithm/models"
+	"github.com/MunishMummadi/mock-trade-algorithm/strategies"
+)
+
+// DefaultStrength is used when a rule file has no strength expression
+const DefaultStrength = 0.6
+
+// Definition is the JSON form of a rule strategy:
+//
+//	{
+//	  "name": "Golden Cross",
+//	  "description": "SMA 20/50 cross filtered by RSI",
+//	  "buy": "crosses_above(sma(close,20), sma(close,50)) and rsi(close,14) < 70",
+//	  "sell": "crosses_below(sma(close,20), sma(close,50))",
+//	  "strength": "0.5 + abs(sma(close,20) - sma(close,50)) / atr(14) / 4"
+//	}
+//
+// At least one of buy and sell is required. Strength is a number expression
+// clamped to [0, 1].
+type Definition struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Buy         string `json:"buy"`
+	Sell        string `json:"sell"`
+	Strength    string `json:"strength"`
+}
+
+// Strategy is a strategy whose entry and exit rules are expressions. It
+// implements strategies.FeatureStrategy, so it runs alongside the built-in
+// strategies and shares their indicator cache.
+type Strategy struct {
+	name        string
+	description string
+	buy         *Expr
+	sell        *Expr
+	strength    *Expr
+}
+
+// NewStrategy compiles a definition
+func NewStrategy(def Definition) (*Strategy, error) {
+	if strings.TrimSpace(def.Name) == "" {
+		return nil, fmt.Errorf("rule strategy has no name")
+	}
+	if def.Buy == "" && def.Sell == "" {
+		return nil, fmt.Errorf("rule strategy %q has neither a buy nor a sell rule", def.Name)
+	}
+
+	s := &Strategy{name: def.Name, description: def.Description}
+	if s.description == "" {
+		s.description = "Rule strategy"
+	}
+
+	var err error
+	if s.buy, err = compileRule(def.Buy, true); err != nil {
+		return nil, fmt.Errorf("invalid buy rule of %q: %w", def.Name, err)
+	}
+	if s.sell, err = compileRule(def.Sell, true); err != nil {
+		return nil, fmt.Errorf("invalid sell rule of %q: %w", def.Name, err)
+	}
+	if s.strength, err = compileRule(def.Strength, false); err != nil {
+		return nil, fmt.Errorf("invalid strength of %q: %w", def.Name, err)
+	}
+	return s, nil
+}
+
+// compileRule compiles an optional rule, checking it is a condition or a
+// number as required
+func compileRule(src string, condition bool) (*Expr, error) {
+	if strings.TrimSpace(src) == "" {
+		return nil, nil
+	}
+
+	x, err := Compile(src)
+	if err != nil {
+		return nil, err
+	}
+	if x.IsCondition() != condition {
+		want := "a number"
+		if condition {
+			want = "a condition, such as a comparison"
+		}
+		return nil, fmt.Errorf("must be %s", want)
+	}
+	return x, nil
+}
+
+// LoadFile reads a rule strategy from a JSON file
+func LoadFile(path string) (*Strategy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rule file: %w", err)
+	}
+
+	var def Definition
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&def); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	s, err := NewStrategy(def)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return s, nil
+}
+
+// LoadDir loads every *.json file in dir, in name order
+func LoadDir(dir string) ([]*Strategy, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rule files: %w", err)
+	}
+	if _, err := os.Stat(dir); err != nil {
+		return nil, fmt.Errorf("failed to open rule directory: %w", err)
+	}
+	sort.Strings(paths)
+
+	var loaded []*Strategy
+	names := make(map[string]string)
+	for _, path := range paths {
+		s, err := LoadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		key := strings.ToLower(s.name)
+		if other, exists := names[key]; exists {
+			return nil, fmt.Errorf("rule strategy %q is defined in both %s and %s", s.name, other, path)
+		}
+		names[key] = path
+		loaded = append(loaded, s)
+	}
+	return loaded, nil
+}
+
+func (s *Strategy) GetName() string {
+	return s.name
+}
+
+func (s *Strategy) GetDescription() string {
+	return s.description
+}
+
+// Analyze implements the Strategy interface
+func (s *Strategy) Analyze(symbol string, bars []alpaca.MockBar, currentPrice decimal.Decimal) *models.TradingSignal {
+	return s.AnalyzeFeatures(strategies.NewFeatures(symbol, bars), currentPrice)
+}
+
+// AnalyzeFeatures implements the FeatureStrategy interface. When both rules
+// hold on the same bar they cancel out and no signal is produced.
+func (s *Strategy) AnalyzeFeatures(features *strategies.Features, currentPrice decimal.Decimal) *models.TradingSignal {
+	if features.Len() < 2 {
+		return nil
+	}
+	e := newEvaluator(features, currentPrice)
+
+	buy := s.buy != nil && e.holds(s.buy)
+	sell := s.sell != nil && e.holds(s.sell)
+	if buy == sell {
+		return nil
+	}
+
+	signal, rule := "BUY", s.buy
+	if sell {
+		signal, rule = "SELL", s.sell
+	}
+
+	strength := DefaultStrength
+	values := e.values(rule)
+	if s.strength != nil {
+		if v := e.last(s.strength); !math.IsNaN(v) && !math.IsInf(v, 0) {
+			strength = math.Max(0, math.Min(1, v))
+		}
+		for key, v := range e.values(s.strength) {
+			values[key] = v
+		}
+	}
+
+	return &models.TradingSignal{
+		Symbol:     features.Symbol,
+		Signal:     signal,
+		Strength:   strength,
+		Price:      currentPrice,
+		Strategy:   s.name,
+		CreatedAt:  time.Now(),
+		Indicators: values,
+		Reason:     fmt.Sprintf("%s rule matched: %s", strings.ToLower(signal), rule),
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/MunishMummadi/mock-trade-algorithm/models"
+	"github.com/MunishMummadi/mock-trade-algorithm/strategies"
+)
+
+// shadowing reports whether any strategy runs in shadow mode
+func (e *TradingEngine) shadowing() bool {
+	return len(e.shadowStrategies) > 0 || len(e.shadowUniverse) > 0
+}
+
+// processShadow runs the shadow strategies over the cycle's universe and
+// fills their signals into the shadow book. Shadow signals are recorded for
+// analysis but never reach aggregation, adaptive weighting or the paper
+// account.
+func (e *TradingEngine) processShadow(ctx context.Context, universe map[string]*strategies.Features,
+	prices map[string]decimal.Decimal) {
+
+	bySymbol, multiLeg := e.analyzeUniverse(ctx, e.shadowUniverse, universe, prices)
+
+	// Single-symbol signals are gated by regime as they would be live
+	signals := multiLeg
+	for _, symbol := range strategies.Universe(universe) {
+		symbolSignals := append(bySymbol[symbol], e.analyzeSymbol(ctx, e.shadowStrategies, universe[symbol], prices[symbol])...)
+		for _, signal := range symbolSignals {
+			e.calibrator.Calibrate(signal)
+		}
+
+		kept, disabled := e.regimes.Apply(symbol, symbolSignals)
+		e.recordDisabled(disabled)
+		signals = append(signals, kept...)
+	}
+
+	for _, signal := range signals {
+		signal.Decision = models.SignalDecisionShadow
+
+		fills, err := e.shadowBook.Execute(signal, prices)
+		if err != nil {
+			log.Printf("Warning: failed to fill shadow signal from %s for %s: %v", signal.Strategy, signal.Symbol, err)
+		}
+		for _, fill := range fills {
+			e.notifyStrategy(e.strategyByName(signal.Strategy), fill)
+		}
+
+		if err := e.db.CreateTradingSignal(signal); err != nil {
+			log.Printf("Warning: %v", err)
+		}
+	}
+}
+
+// recordEquity snapshots the live account and every shadow portfolio at the
+// same prices
+func (e *TradingEngine) recordEquity(user *models.User, portfolio []*models.Portfolio,
+	prices map[string]decimal.Decimal, at time.Time) {
+
+	live := &models.EquitySnapshot{
+		Account:   models.LiveAccount,
+		Cash:      user.Balance,
+		Equity:    e.totalValue(user, portfolio),
+		CreatedAt: at,
+	}
+	if err := e.db.CreateEquitySnapshot(live); err != nil {
+		log.Printf("Warning: %v", err)
+		return
+	}
+	if err := e.shadowBook.Snapshot(prices, at); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+}
@@ -0,0 +1,274 @@
+package shadow
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/MunishMummadi/mock-trade-algorithm/config"
+	"github.com/MunishMummadi/mock-trade-algorithm/database"
+	"github.com/MunishMummadi/mock-trade-algorithm/models"
+	"github.com/MunishMummadi/mock-trade-algorithm/symbols"
+)
+
+// Config controls how shadow signals are sized and filled. Sizing mirrors
+// the live engine so results are comparable.
+type Config struct {
+	InitialBalance  decimal.Decimal
+	MaxPositionSize decimal.Decimal
+	RiskPercentage  decimal.Decimal
+	Slippage        decimal.Decimal // fraction of the price paid on every fill
+}
+
+// ConfigFromConfig builds the shadow settings from application config
+func ConfigFromConfig(cfg *config.Config) Config {
+	return Config{
+		InitialBalance:  decimal.NewFromFloat(cfg.ShadowInitialBalance),
+		MaxPositionSize: decimal.NewFromFloat(cfg.MaxPositionSize),
+		RiskPercentage:  decimal.NewFromFloat(cfg.RiskPercentage),
+		Slippage:        decimal.NewFromFloat(cfg.ShadowSlippage),
+	}
+}
+
+// ParseStrategies splits a comma separated list of shadow strategy names
+func ParseStrategies(value string) map[string]bool {
+	names := make(map[string]bool)
+	for _, name := range strings.Split(value, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names[strings.ToLower(name)] = true
+		}
+	}
+	return names
+}
+
+// Book holds the virtual portfolios of all shadow strategies
+type Book struct {
+	db       *database.Database
+	registry *symbols.Registry
+	config   Config
+
+	mu         sync.Mutex
+	portfolios map[string]*Portfolio
+}
+
+func NewBook(db *database.Database, registry *symbols.Registry, cfg Config) *Book {
+	return &Book{
+		db:         db,
+		registry:   registry,
+		config:     cfg,
+		portfolios: make(map[string]*Portfolio),
+	}
+}
+
+// Load opens a portfolio for every shadow strategy, replaying its persisted
+// trades so virtual positions survive restarts
+func (b *Book) Load(strategies []string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, strategy := range strategies {
+		trades, err := b.db.GetShadowTrades(strategy)
+		if err != nil {
+			return err
+		}
+
+		portfolio := newPortfolio(strategy, b.config.InitialBalance)
+		for _, trade := range trades {
+			portfolio.apply(trade)
+		}
+		b.portfolios[strategy] = portfolio
+	}
+	return nil
+}
+
+// Execute fills a shadow signal into its strategy's portfolio and returns
+// the hypothetical fills, shaped as trades so they can be passed to the
+// strategy's fill hooks. Signals the live engine would not act on, such as
+// a BUY while already long, produce no fills.
+func (b *Book) Execute(signal *models.TradingSignal, prices map[string]decimal.Decimal) ([]*models.Trade, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	portfolio, exists := b.portfolios[signal.Strategy]
+	if !exists {
+		return nil, fmt.Errorf("%s is not a shadow strategy", signal.Strategy)
+	}
+
+	var orders []*models.ShadowTrade
+	var err error
+	if len(signal.Legs) > 0 {
+		orders, err = b.legOrders(signal, prices)
+	} else {
+		orders, err = b.order(portfolio, signal, prices)
+	}
+	if err != nil || len(orders) == 0 {
+		return nil, err
+	}
+
+	// Buys must be covered by cash, as in the live account
+	cost := decimal.Zero
+	for _, order := range orders {
+		if order.Side == models.OrderSideBuy {
+			cost = cost.Add(order.Quantity.Mul(order.Price))
+		}
+	}
+	if cost.GreaterThan(portfolio.Cash) {
+		return nil, nil
+	}
+
+	// Legs are recorded together so a restart never replays half a signal
+	if err := b.db.CreateShadowTrades(orders); err != nil {
+		return nil, err
+	}
+
+	fills := make([]*models.Trade, 0, len(orders))
+	for _, order := range orders {
+		portfolio.apply(order)
+
+		trade := models.NewTrade(0, order.Symbol, order.Side, models.TradeTypeMarket,
+			order.Quantity, prices[order.Symbol], order.Strategy)
+		trade.MarkFilled(order.Price, decimal.Zero)
+		trade.Notes = order.Notes
+		fills = append(fills, trade)
+
+		log.Printf("Shadow fill for %s: %s %s %s @ $%.2f", order.Strategy,
+			order.Side, order.Quantity.String(), order.Symbol, order.Price.InexactFloat64())
+	}
+	return fills, nil
+}
+
+// order sizes a single-symbol signal like the live engine: buy when flat,
+// close the long position on a sell
+func (b *Book) order(portfolio *Portfolio, signal *models.TradingSignal, prices map[string]decimal.Decimal) ([]*models.ShadowTrade, error) {
+	price, exists := prices[signal.Symbol]
+	if !exists {
+		return nil, nil
+	}
+	position := portfolio.Position(signal.Symbol)
+
+	switch signal.Signal {
+	case "BUY":
+		if !position.IsZero() {
+			return nil, nil
+		}
+		asset, err := b.registry.Lookup(signal.Symbol)
+		if err != nil {
+			return nil, err
+		}
+
+		value := portfolio.Cash.Mul(b.config.RiskPercentage).Mul(decimal.NewFromFloat(signal.Strength))
+		if signal.TargetWeight > 0 {
+			value = portfolio.Equity(prices).Mul(decimal.NewFromFloat(signal.TargetWeight))
+		}
+		quantity := asset.RoundQuantity(decimal.Min(value, b.config.MaxPositionSize).Div(price))
+		if !quantity.IsPositive() {
+			return nil, nil
+		}
+		return []*models.ShadowTrade{b.fill(signal, signal.Symbol, models.OrderSideBuy, quantity, price, signal.Reason)}, nil
+
+	case "SELL":
+		if !position.IsPositive() {
+			return nil, nil
+		}
+		return []*models.ShadowTrade{b.fill(signal, signal.Symbol, models.OrderSideSell, position, price, signal.Reason)}, nil
+	}
+	return nil, nil
+}
+
+// legOrders sizes a multi-leg signal like the live engine, splitting the
+// gross notional by leg weight unless the leg gives a quantity
+func (b *Book) legOrders(signal *models.TradingSignal, prices map[string]decimal.Decimal) ([]*models.ShadowTrade, error) {
+	gross := b.config.MaxPositionSize.Mul(decimal.NewFromFloat(signal.Strength))
+
+	orders := make([]*models.ShadowTrade, 0, len(signal.Legs))
+	for i, leg := range signal.Legs {
+		price, exists := prices[leg.Symbol]
+		if !exists {
+			return nil, fmt.Errorf("price not available for leg %s", leg.Symbol)
+		}
+		asset, err := b.registry.Lookup(leg.Symbol)
+		if err != nil {
+			return nil, err
+		}
+
+		quantity := leg.Quantity
+		if !quantity.IsPositive() {
+			quantity = asset.RoundQuantity(gross.Mul(decimal.NewFromFloat(leg.Weight)).Div(price))
+		}
+		if !quantity.IsPositive() {
+			return nil, nil
+		}
+
+		notes := fmt.Sprintf("leg %d of %d: %s", i+1, len(signal.Legs), signal.Reason)
+		orders = append(orders, b.fill(signal, leg.Symbol, leg.Side, quantity, price, notes))
+	}
+	return orders, nil
+}
+
+// fill prices a hypothetical market order with slippage against the trader
+func (b *Book) fill(signal *models.TradingSignal, symbol string, side models.OrderSide,
+	quantity, price decimal.Decimal, notes string) *models.ShadowTrade {
+
+	slippage := price.Mul(b.config.Slippage)
+	if side == models.OrderSideSell {
+		slippage = slippage.Neg()
+	}
+
+	return &models.ShadowTrade{
+		Strategy:  signal.Strategy,
+		Symbol:    symbol,
+		Side:      side,
+		Quantity:  quantity,
+		Price:     price.Add(slippage),
+		Notes:     notes,
+		CreatedAt: time.Now(),
+	}
+}
+
+// Snapshot records every shadow portfolio's equity at the current prices
+func (b *Book) Snapshot(prices map[string]decimal.Decimal, at time.Time) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, strategy := range b.strategies() {
+		portfolio := b.portfolios[strategy]
+		snapshot := &models.EquitySnapshot{
+			Account:   models.ShadowAccount(strategy),
+			Cash:      portfolio.Cash,
+			Equity:    portfolio.Equity(prices),
+			CreatedAt: at,
+		}
+		if err := b.db.CreateEquitySnapshot(snapshot); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LogSummary logs one line per shadow portfolio
+func (b *Book) LogSummary(prices map[string]decimal.Decimal) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, strategy := range b.strategies() {
+		portfolio := b.portfolios[strategy]
+		equity := portfolio.Equity(prices)
+		change := equity.Div(b.config.InitialBalance).Sub(decimal.NewFromInt(1)).Mul(decimal.NewFromInt(100))
+		log.Printf("Shadow %s: equity $%.2f (%+.2f%%), %d positions, %d trades",
+			strategy, equity.InexactFloat64(), change.InexactFloat64(), len(portfolio.Positions), portfolio.Trades)
+	}
+}
+
+func (b *Book) strategies() []string {
+	names := make([]string, 0, len(b.portfolios))
+	for name := range b.portfolios {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,201 @@
+package shadow
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/MunishMummadi/mock-trade-algorithm/database"
+	"github.com/MunishMummadi/mock-trade-algorithm/models"
+	"github.com/MunishMummadi/mock-trade-algorithm/symbols"
+)
+
+const testStrategy = "Candidate"
+
+// newTestBook opens a book for testStrategy on a fresh database with 10000
+// of cash, 0.1% slippage and trades risking 10% of cash at full strength
+func newTestBook(t *testing.T) (*Book, string) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "test.db")
+	db, err := database.New(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	registry, err := symbols.Load(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	book := NewBook(db, registry, testConfig())
+	if err := book.Load([]string{testStrategy}); err != nil {
+		t.Fatal(err)
+	}
+	return book, path
+}
+
+func testConfig() Config {
+	return Config{
+		InitialBalance:  decimal.NewFromInt(10000),
+		MaxPositionSize: decimal.NewFromInt(5000),
+		RiskPercentage:  decimal.NewFromFloat(0.1),
+		Slippage:        decimal.NewFromFloat(0.001),
+	}
+}
+
+func testSignal(side string, strength float64) *models.TradingSignal {
+	return &models.TradingSignal{Symbol: "AAPL", Signal: side, Strength: strength,
+		Price: decimal.NewFromInt(200), Strategy: testStrategy}
+}
+
+func assertDecimal(t *testing.T, name string, got decimal.Decimal, want string) {
+	t.Helper()
+	if !got.Equal(decimal.RequireFromString(want)) {
+		t.Fatalf("%s = %s, want %s", name, got, want)
+	}
+}
+
+func TestExecuteFillsVirtually(t *testing.T) {
+	book, _ := newTestBook(t)
+	prices := map[string]decimal.Decimal{"AAPL": decimal.NewFromInt(200)}
+
+	// 10% of 10000 at strength 0.5 buys 2 whole shares at 200 plus slippage
+	fills, err := book.Execute(testSignal("BUY", 0.5), prices)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fills) != 1 || fills[0].Side != models.OrderSideBuy || fills[0].Status != models.TradeStatusFilled {
+		t.Fatalf("buy fills %+v, want one filled buy", fills)
+	}
+	assertDecimal(t, "buy quantity", fills[0].Quantity, "2")
+	assertDecimal(t, "buy price", fills[0].FillPrice, "200.2")
+
+	portfolio := book.portfolios[testStrategy]
+	assertDecimal(t, "cash after buy", portfolio.Cash, "9599.6")
+	assertDecimal(t, "position after buy", portfolio.Position("AAPL"), "2")
+
+	// A BUY while long is ignored, as live
+	if fills, err := book.Execute(testSignal("BUY", 1), prices); err != nil || len(fills) != 0 {
+		t.Fatalf("buy while long gave %+v, %v", fills, err)
+	}
+
+	// A SELL closes the whole position below the entry price
+	fills, err = book.Execute(testSignal("SELL", 0.5), prices)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fills) != 1 || fills[0].Side != models.OrderSideSell {
+		t.Fatalf("sell fills %+v, want one sell", fills)
+	}
+	assertDecimal(t, "sell price", fills[0].FillPrice, "199.8")
+	assertDecimal(t, "cash after sell", portfolio.Cash, "9999.2")
+	if !portfolio.Position("AAPL").IsZero() || portfolio.Trades != 2 || portfolio.Closed != 1 || portfolio.Wins != 0 {
+		t.Fatalf("after round trip: %+v", portfolio)
+	}
+
+	if _, err := book.Execute(&models.TradingSignal{Symbol: "AAPL", Signal: "BUY", Strategy: "Live"}, prices); err == nil {
+		t.Fatal("executed a signal of a strategy outside the book")
+	}
+}
+
+func TestExecuteRequiresCash(t *testing.T) {
+	book, _ := newTestBook(t)
+	prices := map[string]decimal.Decimal{"AAPL": decimal.NewFromInt(200), "MSFT": decimal.NewFromInt(400)}
+
+	// 60 AAPL costs 12000 against 10000 of cash
+	signal := testSignal("BUY", 1)
+	signal.Legs = []models.SignalLeg{
+		{Symbol: "AAPL", Side: models.OrderSideBuy, Quantity: decimal.NewFromInt(60)},
+		{Symbol: "MSFT", Side: models.OrderSideSell, Quantity: decimal.NewFromInt(30)},
+	}
+	fills, err := book.Execute(signal, prices)
+	if err != nil || len(fills) != 0 {
+		t.Fatalf("unaffordable legs gave %+v, %v", fills, err)
+	}
+
+	trades, err := book.db.GetShadowTrades(testStrategy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(trades) != 0 {
+		t.Fatalf("unaffordable legs persisted %d trades", len(trades))
+	}
+	assertDecimal(t, "cash", book.portfolios[testStrategy].Cash, "10000")
+}
+
+func TestLoadReplaysPersistedTrades(t *testing.T) {
+	book, _ := newTestBook(t)
+	prices := map[string]decimal.Decimal{"AAPL": decimal.NewFromInt(200), "MSFT": decimal.NewFromInt(400)}
+
+	pair := testSignal("BUY", 1)
+	pair.Legs = []models.SignalLeg{
+		{Symbol: "AAPL", Side: models.OrderSideBuy, Weight: 0.5},
+		{Symbol: "MSFT", Side: models.OrderSideSell, Weight: 0.5},
+	}
+	for _, signal := range []*models.TradingSignal{testSignal("BUY", 1), pair} {
+		if _, err := book.Execute(signal, prices); err != nil {
+			t.Fatal(err)
+		}
+	}
+	before := book.portfolios[testStrategy]
+
+	// A restart rebuilds the portfolio from the database alone
+	restarted := NewBook(book.db, book.registry, testConfig())
+	if err := restarted.Load([]string{testStrategy}); err != nil {
+		t.Fatal(err)
+	}
+	after := restarted.portfolios[testStrategy]
+
+	assertDecimal(t, "replayed cash", after.Cash, before.Cash.String())
+	if len(after.Positions) != 2 || after.Trades != before.Trades {
+		t.Fatalf("replayed %+v, want %+v", after, before)
+	}
+	for symbol, quantity := range before.Positions {
+		assertDecimal(t, "replayed "+symbol, after.Position(symbol), quantity.String())
+	}
+	assertDecimal(t, "short MSFT leg", after.Position("MSFT"), "-6")
+}
+
+func TestExecutePersistsLegsTogether(t *testing.T) {
+	book, path := newTestBook(t)
+	prices := map[string]decimal.Decimal{"AAPL": decimal.NewFromInt(200), "MSFT": decimal.NewFromInt(400)}
+
+	// Make the second leg's insert fail
+	conn, err := sql.Open("sqlite3", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	_, err = conn.Exec(`CREATE TRIGGER fail_msft BEFORE INSERT ON shadow_trades
+		WHEN NEW.symbol = 'MSFT' BEGIN SELECT RAISE(ABORT, 'leg rejected'); END`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signal := testSignal("BUY", 1)
+	signal.Legs = []models.SignalLeg{
+		{Symbol: "AAPL", Side: models.OrderSideBuy, Weight: 0.5},
+		{Symbol: "MSFT", Side: models.OrderSideSell, Weight: 0.5},
+	}
+	fills, err := book.Execute(signal, prices)
+	if err == nil || len(fills) != 0 {
+		t.Fatalf("failed leg gave %+v, %v; want an error and no fills", fills, err)
+	}
+
+	// Neither leg is applied or persisted
+	portfolio := book.portfolios[testStrategy]
+	if len(portfolio.Positions) != 0 || portfolio.Trades != 0 {
+		t.Fatalf("failed signal changed the portfolio: %+v", portfolio)
+	}
+	trades, err := book.db.GetShadowTrades(testStrategy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(trades) != 0 {
+		t.Fatalf("failed signal persisted %d legs", len(trades))
+	}
+}
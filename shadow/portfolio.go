@@ -0,0 +1,101 @@
+// Package shadow runs candidate strategies against the live market feed
+// without letting them trade: each shadow strategy's signals are filled
+// hypothetically into its own virtual portfolio, kept apart from the paper
+// account, so its performance can be compared with the live ensemble before
+// it is promoted.
+package shadow
+
+import (
+	"github.com/shopspring/decimal"
+
+	"github.com/MunishMummadi/mock-trade-algorithm/models"
+)
+
+// Portfolio is a shadow strategy's virtual account. Positions are signed so
+// short legs of multi-leg signals are represented.
+type Portfolio struct {
+	Strategy  string
+	Cash      decimal.Decimal
+	Positions map[string]decimal.Decimal
+	Trades    int
+	Closed    int // trades that reduced a position
+	Wins      int // reducing trades that realized a profit
+
+	costs      map[string]decimal.Decimal // average entry price per position
+	lastPrices map[string]decimal.Decimal
+}
+
+func newPortfolio(strategy string, cash decimal.Decimal) *Portfolio {
+	return &Portfolio{
+		Strategy:   strategy,
+		Cash:       cash,
+		Positions:  make(map[string]decimal.Decimal),
+		costs:      make(map[string]decimal.Decimal),
+		lastPrices: make(map[string]decimal.Decimal),
+	}
+}
+
+// Position returns the signed quantity held in symbol
+func (p *Portfolio) Position(symbol string) decimal.Decimal {
+	return p.Positions[symbol]
+}
+
+// apply books a fill
+func (p *Portfolio) apply(trade *models.ShadowTrade) {
+	signed := trade.Quantity
+	if trade.Side == models.OrderSideSell {
+		signed = signed.Neg()
+	}
+
+	position := p.Positions[trade.Symbol]
+	cost := p.costs[trade.Symbol]
+
+	// The part of the fill that offsets the existing position realizes P&L
+	if !position.IsZero() && position.Sign() != signed.Sign() {
+		closing := decimal.Min(position.Abs(), signed.Abs())
+		realized := trade.Price.Sub(cost).Mul(closing)
+		if position.IsNegative() {
+			realized = realized.Neg()
+		}
+		p.Closed++
+		if realized.IsPositive() {
+			p.Wins++
+		}
+	}
+
+	updated := position.Add(signed)
+	switch {
+	case updated.IsZero():
+		delete(p.Positions, trade.Symbol)
+		delete(p.costs, trade.Symbol)
+	case position.IsZero() || position.Sign() != updated.Sign():
+		// Opened, or flipped through zero
+		p.Positions[trade.Symbol] = updated
+		p.costs[trade.Symbol] = trade.Price
+	case updated.Abs().GreaterThan(position.Abs()):
+		// Added to the position
+		total := cost.Mul(position.Abs()).Add(trade.Price.Mul(signed.Abs()))
+		p.Positions[trade.Symbol] = updated
+		p.costs[trade.Symbol] = total.Div(updated.Abs())
+	default:
+		p.Positions[trade.Symbol] = updated
+	}
+
+	p.Cash = p.Cash.Sub(signed.Mul(trade.Price))
+	p.lastPrices[trade.Symbol] = trade.Price
+	p.Trades++
+}
+
+// Equity marks the positions to prices, falling back to the last fill price
+// for symbols without a quote
+func (p *Portfolio) Equity(prices map[string]decimal.Decimal) decimal.Decimal {
+	equity := p.Cash
+	for symbol, quantity := range p.Positions {
+		price, exists := prices[symbol]
+		if !exists {
+			price = p.lastPrices[symbol]
+		}
+		equity = equity.Add(quantity.Mul(price))
+	}
+	return equity
+}
@@ -0,0 +1,213 @@
+package shadow
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/MunishMummadi/mock-trade-algorithm/database"
+	"github.com/MunishMummadi/mock-trade-algorithm/models"
+)
+
+// AccountStats summarizes one account's equity curve over the report window
+type AccountStats struct {
+	Account     string
+	Snapshots   int
+	StartEquity float64
+	EndEquity   float64
+	Return      float64 // total return over the window
+	MaxDrawdown float64 // largest peak-to-trough fall, as a fraction of the peak
+	MeanReturn  float64 // mean return per snapshot interval
+	Volatility  float64 // standard deviation of the per-interval returns
+	Excess      float64 // return minus the live account's return; NaN for live
+	Trades      int     // shadow fills in the window; 0 for live
+	WinRate     float64 // share of position-reducing fills that made money
+}
+
+// Report compares each shadow portfolio with the live account over the
+// same window
+type Report struct {
+	GeneratedAt time.Time
+	From        time.Time
+	Live        *AccountStats
+	Shadows     []*AccountStats
+}
+
+// Compare builds the shadow vs live report from the equity snapshots taken
+// at or after from. The window starts at the first shadow snapshot so live
+// history from before shadow mode was enabled does not skew the comparison.
+func Compare(db *database.Database, from time.Time) (*Report, error) {
+	snapshots, err := db.GetEquitySnapshots(from)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{GeneratedAt: time.Now(), From: from}
+	for _, snapshot := range snapshots {
+		if snapshot.Account != models.LiveAccount {
+			report.From = snapshot.CreatedAt
+			break
+		}
+	}
+
+	curves := make(map[string][]*models.EquitySnapshot)
+	for _, snapshot := range snapshots {
+		if !snapshot.CreatedAt.Before(report.From) {
+			curves[snapshot.Account] = append(curves[snapshot.Account], snapshot)
+		}
+	}
+
+	if live, exists := curves[models.LiveAccount]; exists {
+		report.Live = curveStats(models.LiveAccount, live)
+	}
+
+	for account, curve := range curves {
+		strategy, isShadow := strings.CutPrefix(account, models.ShadowAccount(""))
+		if !isShadow {
+			continue
+		}
+
+		stats := curveStats(account, curve)
+		stats.Excess = math.NaN()
+		if report.Live != nil {
+			stats.Excess = stats.Return - report.Live.Return
+		}
+		if err := tradeStats(db, strategy, report.From, stats); err != nil {
+			return nil, err
+		}
+		report.Shadows = append(report.Shadows, stats)
+	}
+	sort.Slice(report.Shadows, func(i, j int) bool {
+		return report.Shadows[i].Account < report.Shadows[j].Account
+	})
+
+	return report, nil
+}
+
+func curveStats(account string, curve []*models.EquitySnapshot) *AccountStats {
+	stats := &AccountStats{
+		Account:     account,
+		Snapshots:   len(curve),
+		StartEquity: curve[0].Equity.InexactFloat64(),
+		EndEquity:   curve[len(curve)-1].Equity.InexactFloat64(),
+		Return:      math.NaN(),
+		MeanReturn:  math.NaN(),
+		Volatility:  math.NaN(),
+		Excess:      math.NaN(),
+		WinRate:     math.NaN(),
+	}
+	if stats.StartEquity > 0 {
+		stats.Return = stats.EndEquity/stats.StartEquity - 1
+	}
+
+	var returns []float64
+	peak := stats.StartEquity
+	for i, snapshot := range curve {
+		equity := snapshot.Equity.InexactFloat64()
+		if equity > peak {
+			peak = equity
+		}
+		if peak > 0 {
+			stats.MaxDrawdown = math.Max(stats.MaxDrawdown, 1-equity/peak)
+		}
+		if i > 0 {
+			if previous := curve[i-1].Equity.InexactFloat64(); previous > 0 {
+				returns = append(returns, equity/previous-1)
+			}
+		}
+	}
+
+	if len(returns) > 0 {
+		var sum float64
+		for _, r := range returns {
+			sum += r
+		}
+		stats.MeanReturn = sum / float64(len(returns))
+	}
+	if len(returns) > 1 {
+		var squares float64
+		for _, r := range returns {
+			squares += (r - stats.MeanReturn) * (r - stats.MeanReturn)
+		}
+		stats.Volatility = math.Sqrt(squares / float64(len(returns)-1))
+	}
+	return stats
+}
+
+// tradeStats replays the strategy's shadow fills to count the trades and
+// wins at or after from. Earlier fills are replayed too, since they set the
+// cost basis of the positions later fills reduce.
+func tradeStats(db *database.Database, strategy string, from time.Time, stats *AccountStats) error {
+	trades, err := db.GetShadowTrades(strategy)
+	if err != nil {
+		return err
+	}
+
+	portfolio := newPortfolio(strategy, decimal.Zero)
+	var tradesBefore, closedBefore, winsBefore int
+	for _, trade := range trades {
+		portfolio.apply(trade)
+		if trade.CreatedAt.Before(from) {
+			tradesBefore, closedBefore, winsBefore = portfolio.Trades, portfolio.Closed, portfolio.Wins
+		}
+	}
+
+	stats.Trades = portfolio.Trades - tradesBefore
+	if closed := portfolio.Closed - closedBefore; closed > 0 {
+		stats.WinRate = float64(portfolio.Wins-winsBefore) / float64(closed)
+	}
+	return nil
+}
+
+// Print writes the report as a plain text table, live account first
+func (r *Report) Print(w io.Writer) error {
+	fmt.Fprintf(w, "Shadow vs live report (generated %s, from %s)\n\n",
+		r.GeneratedAt.Format(time.RFC3339), r.From.Format(time.RFC3339))
+
+	if len(r.Shadows) == 0 {
+		fmt.Fprintln(w, "No shadow equity snapshots recorded")
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "account\tsnapshots\tstart\tend\treturn\tvs live\tmax dd\tmean\tvol\ttrades\twin")
+
+	accounts := r.Shadows
+	if r.Live != nil {
+		accounts = append([]*AccountStats{r.Live}, accounts...)
+	}
+	for _, s := range accounts {
+		trades := "-"
+		if s.Account != models.LiveAccount {
+			trades = fmt.Sprint(s.Trades)
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%.2f\t%.2f\t%s\t%s\t%.2f%%\t%s\t%s\t%s\t%s\n",
+			s.Account, s.Snapshots, s.StartEquity, s.EndEquity, percent(s.Return), percent(s.Excess),
+			s.MaxDrawdown*100, percent(s.MeanReturn), rate(s.Volatility), trades, rate(s.WinRate))
+	}
+
+	if err := tw.Flush(); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	return nil
+}
+
+func rate(v float64) string {
+	if math.IsNaN(v) {
+		return "-"
+	}
+	return fmt.Sprintf("%.2f%%", v*100)
+}
+
+func percent(v float64) string {
+	if math.IsNaN(v) {
+		return "-"
+	}
+	return fmt.Sprintf("%+.2f%%", v*100)
+}
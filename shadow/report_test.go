@@ -0,0 +1,74 @@
+package shadow
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/MunishMummadi/mock-trade-algorithm/models"
+)
+
+func TestCompareCountsTradesInWindow(t *testing.T) {
+	book, _ := newTestBook(t)
+	start := time.Date(2026, 5, 4, 15, 0, 0, 0, time.UTC)
+	from := start.Add(24 * time.Hour)
+
+	trade := func(side models.OrderSide, price int64, at time.Time) *models.ShadowTrade {
+		return &models.ShadowTrade{Strategy: testStrategy, Symbol: "AAPL", Side: side,
+			Quantity: decimal.NewFromInt(10), Price: decimal.NewFromInt(price), CreatedAt: at}
+	}
+	snapshot := func(account string, equity int64, at time.Time) {
+		err := book.db.CreateEquitySnapshot(&models.EquitySnapshot{Account: account,
+			Cash: decimal.NewFromInt(equity), Equity: decimal.NewFromInt(equity), CreatedAt: at})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// A losing round trip before the window, then a buy before it that is
+	// sold at a profit inside it, and a losing round trip inside it
+	trades := []*models.ShadowTrade{
+		trade(models.OrderSideBuy, 200, start),
+		trade(models.OrderSideSell, 190, start.Add(time.Hour)),
+		trade(models.OrderSideBuy, 180, start.Add(2*time.Hour)),
+		trade(models.OrderSideSell, 210, from.Add(time.Hour)),
+		trade(models.OrderSideBuy, 220, from.Add(2*time.Hour)),
+		trade(models.OrderSideSell, 215, from.Add(3*time.Hour)),
+	}
+	if err := book.db.CreateShadowTrades(trades); err != nil {
+		t.Fatal(err)
+	}
+
+	snapshot(models.LiveAccount, 10000, start)
+	snapshot(models.LiveAccount, 10000, from)
+	snapshot(models.LiveAccount, 10500, from.Add(4*time.Hour))
+	snapshot(models.ShadowAccount(testStrategy), 10000, from)
+	snapshot(models.ShadowAccount(testStrategy), 9000, from.Add(2*time.Hour))
+	snapshot(models.ShadowAccount(testStrategy), 10200, from.Add(4*time.Hour))
+
+	report, err := Compare(book.db, start)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The window starts at the first shadow snapshot
+	if !report.From.Equal(from) {
+		t.Fatalf("report starts at %s, want %s", report.From, from)
+	}
+	if report.Live == nil || report.Live.Snapshots != 2 || math.Abs(report.Live.Return-0.05) > 1e-9 {
+		t.Fatalf("live stats %+v, want two snapshots returning 5%%", report.Live)
+	}
+	if len(report.Shadows) != 1 {
+		t.Fatalf("got %d shadow accounts, want 1", len(report.Shadows))
+	}
+
+	stats := report.Shadows[0]
+	if stats.Trades != 3 || math.Abs(stats.WinRate-0.5) > 1e-9 {
+		t.Fatalf("%d trades winning %.2f in the window, want 3 winning 0.50", stats.Trades, stats.WinRate)
+	}
+	if math.Abs(stats.Return-0.02) > 1e-9 || math.Abs(stats.Excess+0.03) > 1e-9 || math.Abs(stats.MaxDrawdown-0.1) > 1e-9 {
+		t.Fatalf("shadow curve stats %+v", stats)
+	}
+}
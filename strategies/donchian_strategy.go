@@ -0,0 +1,99 @@
+package strategies
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/MunishMummadi/mock-trade-algorithm/alpaca"
+	"github.com/MunishMummadi/mock-trade-algorithm/models"
+)
+
+// DonchianStrategy implements a Donchian channel breakout strategy
+type DonchianStrategy struct {
+	BaseStrategy
+	period int
+}
+
+// NewDonchianStrategy creates a new Donchian breakout strategy
+func NewDonchianStrategy(period int) *DonchianStrategy {
+	return &DonchianStrategy{
+		BaseStrategy: BaseStrategy{
+			name:        "Donchian Breakout",
+			description: "Donchian channel breakout strategy",
+		},
+		period: period,
+	}
+}
+
+// Analyze implements the Strategy interface
+func (d *DonchianStrategy) Analyze(symbol string, bars []alpaca.MockBar, currentPrice decimal.Decimal) *models.TradingSignal {
+	return d.AnalyzeFeatures(NewFeatures(symbol, bars), currentPrice)
+}
+
+// AnalyzeFeatures implements the FeatureStrategy interface
+func (d *DonchianStrategy) AnalyzeFeatures(features *Features, currentPrice decimal.Decimal) *models.TradingSignal {
+	if features.Len() < d.period {
+		return nil
+	}
+
+	// The channel is built from the last period bars
+	highs := features.Highs()
+	lows := features.Lows()
+
+	upper := math.Inf(-1)
+	lower := math.Inf(1)
+	for i := len(highs) - d.period; i < len(highs); i++ {
+		upper = math.Max(upper, highs[i])
+		lower = math.Min(lower, lows[i])
+	}
+
+	channelWidth := upper - lower
+	if channelWidth <= 0 {
+		return nil
+	}
+
+	price := currentPrice.InexactFloat64()
+
+	// Determine signal
+	var signal, reason string
+	var distance float64
+
+	if price > upper {
+		// Price broke out above the channel
+		signal = "BUY"
+		distance = price - upper
+		reason = fmt.Sprintf("price %.2f broke above the %d-bar high %.2f", price, d.period, upper)
+	} else if price < lower {
+		// Price broke down below the channel
+		signal = "SELL"
+		distance = lower - price
+		reason = fmt.Sprintf("price %.2f broke below the %d-bar low %.2f", price, d.period, lower)
+	} else {
+		// No breakout
+		return nil
+	}
+
+	// Stronger the further price clears the channel
+	strength := 0.6 + (distance / channelWidth * 2)
+	if strength > 1.0 {
+		strength = 1.0
+	}
+
+	return &models.TradingSignal{
+		Symbol:    features.Symbol,
+		Signal:    signal,
+		Strength:  strength,
+		Price:     currentPrice,
+		Strategy:  d.GetName(),
+		CreatedAt: time.Now(),
+		Indicators: map[string]float64{
+			"channel_high": upper,
+			"channel_low":  lower,
+			"price":        price,
+		},
+		Reason: reason,
+	}
+}
@@ -0,0 +1,99 @@
+package strategies
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/MunishMummadi/mock-trade-algorithm/alpaca"
+	"github.com/MunishMummadi/mock-trade-algorithm/models"
+)
+
+// EMAStrategy implements an Exponential Moving Average crossover strategy
+type EMAStrategy struct {
+	BaseStrategy
+	shortPeriod int
+	longPeriod  int
+}
+
+// NewEMAStrategy creates a new EMA crossover strategy
+func NewEMAStrategy(shortPeriod, longPeriod int) *EMAStrategy {
+	return &EMAStrategy{
+		BaseStrategy: BaseStrategy{
+			name:        "EMA Crossover",
+			description: "Exponential Moving Average crossover strategy",
+		},
+		shortPeriod: shortPeriod,
+		longPeriod:  longPeriod,
+	}
+}
+
+// Analyze implements the Strategy interface
+func (e *EMAStrategy) Analyze(symbol string, bars []alpaca.MockBar, currentPrice decimal.Decimal) *models.TradingSignal {
+	return e.AnalyzeFeatures(NewFeatures(symbol, bars), currentPrice)
+}
+
+// AnalyzeFeatures implements the FeatureStrategy interface
+func (e *EMAStrategy) AnalyzeFeatures(features *Features, currentPrice decimal.Decimal) *models.TradingSignal {
+	symbol := features.Symbol
+	if features.Len() < e.longPeriod+1 {
+		return nil
+	}
+
+	// Calculate short and long EMAs; both series end at the latest bar
+	shortEMA := features.EMA(e.shortPeriod)
+	longEMA := features.EMA(e.longPeriod)
+
+	if len(shortEMA) < 2 || len(longEMA) < 2 {
+		return nil
+	}
+
+	// Get the latest values
+	currentShortEMA := shortEMA[len(shortEMA)-1]
+	currentLongEMA := longEMA[len(longEMA)-1]
+	prevShortEMA := shortEMA[len(shortEMA)-2]
+	prevLongEMA := longEMA[len(longEMA)-2]
+
+	// Determine signal
+	var signal, direction string
+
+	// Check for crossover
+	if prevShortEMA <= prevLongEMA && currentShortEMA > currentLongEMA {
+		// Bullish crossover - short EMA crosses above long EMA
+		signal = "BUY"
+		direction = "above"
+	} else if prevShortEMA >= prevLongEMA && currentShortEMA < currentLongEMA {
+		// Bearish crossover - short EMA crosses below long EMA
+		signal = "SELL"
+		direction = "below"
+	} else {
+		// No clear signal
+		return nil
+	}
+
+	// Calculate strength based on the separation of the averages
+	diff := math.Abs(currentShortEMA-currentLongEMA) / currentLongEMA
+	strength := 0.65 + (diff * 100 * 3)
+	if strength > 1.0 {
+		strength = 1.0
+	}
+
+	return &models.TradingSignal{
+		Symbol:    symbol,
+		Signal:    signal,
+		Strength:  strength,
+		Price:     currentPrice,
+		Strategy:  e.GetName(),
+		CreatedAt: time.Now(),
+		Indicators: map[string]float64{
+			"short_ema":      currentShortEMA,
+			"long_ema":       currentLongEMA,
+			"prev_short_ema": prevShortEMA,
+			"prev_long_ema":  prevLongEMA,
+		},
+		Reason: fmt.Sprintf("EMA(%d) crossed %s EMA(%d): %.2f vs %.2f (was %.2f vs %.2f)",
+			e.shortPeriod, direction, e.longPeriod, currentShortEMA, currentLongEMA, prevShortEMA, prevLongEMA),
+	}
+}
@@ -0,0 +1,182 @@
+package strategies
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/MunishMummadi/mock-trade-algorithm/alpaca"
+	"github.com/MunishMummadi/mock-trade-algorithm/indicators"
+	"github.com/MunishMummadi/mock-trade-algorithm/models"
+)
+
+// FeatureStrategy is implemented by strategies that read their inputs from a
+// shared Features frame instead of deriving them from raw bars
+type FeatureStrategy interface {
+	Strategy
+
+	// AnalyzeFeatures is Analyze over a precomputed feature frame
+	AnalyzeFeatures(features *Features, currentPrice decimal.Decimal) *models.TradingSignal
+}
+
+// Features is one symbol's market data for a trading cycle. Series are
+// computed on first use and memoized, so strategies asking for the same
+// indicator share one computation and all see identical inputs.
+type Features struct {
+	Symbol string
+	Bars   []alpaca.MockBar
+
+	mu     sync.Mutex
+	series map[string][][]float64
+}
+
+func NewFeatures(symbol string, bars []alpaca.MockBar) *Features {
+	return &Features{
+		Symbol: symbol,
+		Bars:   bars,
+		series: make(map[string][][]float64),
+	}
+}
+
+// Len returns the number of bars in the frame
+func (f *Features) Len() int {
+	return len(f.Bars)
+}
+
+// memo returns the cached series for key, computing it on first use
+func (f *Features) memo(key string, compute func() []float64) []float64 {
+	return f.memoMulti(key, func() [][]float64 {
+		return [][]float64{compute()}
+	})[0]
+}
+
+// memoMulti caches indicators that produce several series at once. The lock
+// is not held while computing so computations can depend on other features.
+func (f *Features) memoMulti(key string, compute func() [][]float64) [][]float64 {
+	f.mu.Lock()
+	series, exists := f.series[key]
+	f.mu.Unlock()
+	if exists {
+		return series
+	}
+
+	series = compute()
+
+	f.mu.Lock()
+	f.series[key] = series
+	f.mu.Unlock()
+	return series
+}
+
+// Closes returns closing prices
+func (f *Features) Closes() []float64 {
+	return f.memo("closes", func() []float64 {
+		return indicators.Closes(f.Bars)
+	})
+}
+
+// Highs returns high prices
+func (f *Features) Highs() []float64 {
+	return f.memo("highs", func() []float64 {
+		highs := make([]float64, len(f.Bars))
+		for i, bar := range f.Bars {
+			highs[i] = bar.High
+		}
+		return highs
+	})
+}
+
+// Lows returns low prices
+func (f *Features) Lows() []float64 {
+	return f.memo("lows", func() []float64 {
+		lows := make([]float64, len(f.Bars))
+		for i, bar := range f.Bars {
+			lows[i] = bar.Low
+		}
+		return lows
+	})
+}
+
+// Volumes returns bar volumes
+func (f *Features) Volumes() []float64 {
+	return f.memo("volumes", func() []float64 {
+		volumes := make([]float64, len(f.Bars))
+		for i, bar := range f.Bars {
+			volumes[i] = float64(bar.Volume)
+		}
+		return volumes
+	})
+}
+
+// Returns returns simple close-to-close returns, one per bar after the first
+func (f *Features) Returns() []float64 {
+	return f.memo("returns", func() []float64 {
+		closes := f.Closes()
+		if len(closes) < 2 {
+			return nil
+		}
+		returns := make([]float64, len(closes)-1)
+		for i := 1; i < len(closes); i++ {
+			if closes[i-1] != 0 {
+				returns[i-1] = closes[i]/closes[i-1] - 1
+			}
+		}
+		return returns
+	})
+}
+
+// SMA returns the simple moving average of closes
+func (f *Features) SMA(period int) []float64 {
+	return f.memo(fmt.Sprintf("sma:%d", period), func() []float64 {
+		return indicators.SMA(f.Closes(), period)
+	})
+}
+
+// EMA returns the exponential moving average of closes
+func (f *Features) EMA(period int) []float64 {
+	return f.memo(fmt.Sprintf("ema:%d", period), func() []float64 {
+		return indicators.EMA(f.Closes(), period)
+	})
+}
+
+// RSI returns Wilder's RSI of closes
+func (f *Features) RSI(period int) []float64 {
+	return f.memo(fmt.Sprintf("rsi:%d", period), func() []float64 {
+		return indicators.RSI(f.Closes(), period)
+	})
+}
+
+// ATR returns Wilder's Average True Range
+func (f *Features) ATR(period int) []float64 {
+	return f.memo(fmt.Sprintf("atr:%d", period), func() []float64 {
+		return indicators.ATR(f.Bars, period)
+	})
+}
+
+// BollingerBands returns the upper, middle and lower bands of closes
+func (f *Features) BollingerBands(period int, stdDev float64) ([]float64, []float64, []float64) {
+	bands := f.memoMulti(fmt.Sprintf("bollinger:%d:%g", period, stdDev), func() [][]float64 {
+		upper, middle, lower := indicators.BollingerBands(f.Closes(), period, stdDev)
+		return [][]float64{upper, middle, lower}
+	})
+	return bands[0], bands[1], bands[2]
+}
+
+// MACD returns the MACD line, signal line and histogram of closes
+func (f *Features) MACD(fastPeriod, slowPeriod, signalPeriod int) ([]float64, []float64, []float64) {
+	macd := f.memoMulti(fmt.Sprintf("macd:%d:%d:%d", fastPeriod, slowPeriod, signalPeriod), func() [][]float64 {
+		line, signal, histogram := indicators.MACD(f.Closes(), fastPeriod, slowPeriod, signalPeriod)
+		return [][]float64{line, signal, histogram}
+	})
+	return macd[0], macd[1], macd[2]
+}
+
+// ADX returns Wilder's ADX, +DI and -DI
+func (f *Features) ADX(period int) ([]float64, []float64, []float64) {
+	adx := f.memoMulti(fmt.Sprintf("adx:%d", period), func() [][]float64 {
+		adx, plusDI, minusDI := indicators.ADX(f.Bars, period)
+		return [][]float64{adx, plusDI, minusDI}
+	})
+	return adx[0], adx[1], adx[2]
+}
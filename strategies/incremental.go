@@ -0,0 +1,103 @@
+package strategies
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/MunishMummadi/mock-trade-algorithm/alpaca"
+	"github.com/MunishMummadi/mock-trade-algorithm/models"
+)
+
+// IncrementalStrategy is implemented by strategies that can keep their
+// indicators up to date in O(1) per bar instead of recomputing them from
+// the full history on every call to Analyze
+type IncrementalStrategy interface {
+	Strategy
+
+	// NewState returns empty indicator state for one symbol
+	NewState() IncrementalState
+}
+
+// IncrementalState holds one symbol's running indicators for a strategy
+type IncrementalState interface {
+	// Update folds a new bar into the indicators
+	Update(bar alpaca.MockBar)
+
+	// Signal evaluates the strategy against the current price
+	Signal(symbol string, currentPrice decimal.Decimal) *models.TradingSignal
+
+	// Clone returns an independent copy, so a bar can be folded in
+	// tentatively without changing the original
+	Clone() IncrementalState
+}
+
+// Stream feeds bars into an IncrementalState so callers can pass the latest
+// history window every cycle. Bars are keyed by the timeframe period they
+// fall in. The last bar is taken to be still forming: it is applied to a
+// copy of the state and replaced on the next Advance, and only folded in for
+// good once a bar of a later period arrives. The signal therefore matches
+// AnalyzeFeatures over the same bars. When the history no longer contains
+// the last completed bar as it was folded in, because it was revised or
+// regenerated, the state is rebuilt from the bars passed.
+type Stream struct {
+	strategy  IncrementalStrategy
+	timeframe Timeframe
+	committed IncrementalState // every completed bar
+	period    time.Time        // period of the last completed bar
+	last      alpaca.MockBar   // the last completed bar
+	current   IncrementalState // committed plus the forming bar
+}
+
+func NewStream(strategy IncrementalStrategy, timeframe Timeframe) *Stream {
+	state := strategy.NewState()
+	return &Stream{strategy: strategy, timeframe: timeframe, committed: state, current: state}
+}
+
+// Advance folds in the bars of periods after the last completed one and
+// replaces the forming bar with the latest. Of several bars in one period
+// the last is used.
+func (s *Stream) Advance(bars []alpaca.MockBar) {
+	if !s.period.IsZero() && !s.continues(bars) {
+		s.committed = s.strategy.NewState()
+		s.period = time.Time{}
+	}
+
+	var forming *alpaca.MockBar
+	var formingPeriod time.Time
+	for i := range bars {
+		period := s.timeframe.Start(bars[i].Timestamp)
+		if !period.After(s.period) {
+			continue
+		}
+		if forming != nil && period.After(formingPeriod) {
+			s.committed.Update(*forming)
+			s.period, s.last = formingPeriod, *forming
+		}
+		forming, formingPeriod = &bars[i], period
+	}
+
+	s.current = s.committed
+	if forming != nil {
+		s.current = s.committed.Clone()
+		s.current.Update(*forming)
+	}
+}
+
+// continues reports whether bars still hold the last completed bar
+// unchanged, so the committed state is a prefix of their history
+func (s *Stream) continues(bars []alpaca.MockBar) bool {
+	var found *alpaca.MockBar
+	for i := range bars {
+		if s.timeframe.Start(bars[i].Timestamp).Equal(s.period) {
+			found = &bars[i]
+		}
+	}
+	return found != nil && found.Timestamp.Equal(s.last.Timestamp) &&
+		found.Open == s.last.Open && found.High == s.last.High && found.Low == s.last.Low &&
+		found.Close == s.last.Close && found.Volume == s.last.Volume
+}
+
+func (s *Stream) Signal(symbol string, currentPrice decimal.Decimal) *models.TradingSignal {
+	return s.current.Signal(symbol, currentPrice)
+}
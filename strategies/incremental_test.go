@@ -0,0 +1,152 @@
+package strategies
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/MunishMummadi/mock-trade-algorithm/alpaca"
+	"github.com/MunishMummadi/mock-trade-algorithm/models"
+)
+
+// streamTolerance allows for the streaming indicators' floating point
+// rounding against the batch helpers
+const streamTolerance = 1e-6
+
+// oscillatingBars returns daily bars closing at 16:00 that swing around 100
+// with noise, so the strategies under test signal regularly
+func oscillatingBars(n int, seed int64) []alpaca.MockBar {
+	rng := rand.New(rand.NewSource(seed))
+	start := time.Date(2026, 1, 5, 16, 0, 0, 0, time.UTC)
+
+	bars := make([]alpaca.MockBar, n)
+	prev := 100.0
+	for i := range bars {
+		close := 100 + 8*math.Sin(float64(i)/6) + rng.NormFloat64()*1.5
+		bars[i] = alpaca.MockBar{
+			Timestamp: start.AddDate(0, 0, i),
+			Open:      prev,
+			High:      math.Max(prev, close) + rng.Float64(),
+			Low:       math.Min(prev, close) - rng.Float64(),
+			Close:     close,
+			Volume:    1000 + rng.Int63n(1000),
+		}
+		prev = close
+	}
+	return bars
+}
+
+func assertSameSignal(t *testing.T, step int, got, want *models.TradingSignal) {
+	t.Helper()
+	if (got == nil) != (want == nil) {
+		t.Fatalf("bar %d: stream signal %v, AnalyzeFeatures signal %v", step, got, want)
+	}
+	if got == nil {
+		return
+	}
+	if got.Signal != want.Signal || math.Abs(got.Strength-want.Strength) > streamTolerance {
+		t.Fatalf("bar %d: stream %s %.6f, AnalyzeFeatures %s %.6f",
+			step, got.Signal, got.Strength, want.Signal, want.Strength)
+	}
+	for name, value := range want.Indicators {
+		if math.Abs(got.Indicators[name]-value) > streamTolerance {
+			t.Fatalf("bar %d: stream %s = %.6f, AnalyzeFeatures %.6f", step, name, got.Indicators[name], value)
+		}
+	}
+}
+
+func TestStreamMatchesAnalyzeFeatures(t *testing.T) {
+	incremental := []interface {
+		IncrementalStrategy
+		FeatureStrategy
+	}{
+		NewSMAStrategy(5, 20),
+		NewRSIStrategy(14, 30, 70),
+		NewMeanReversionStrategy(20, 2.0),
+	}
+
+	bars := oscillatingBars(150, 1)
+	for _, strategy := range incremental {
+		t.Run(strategy.GetName(), func(t *testing.T) {
+			stream := NewStream(strategy, TimeframeDay)
+			signals := 0
+
+			for n := 2; n <= len(bars); n++ {
+				window := bars[:n]
+				price := decimal.NewFromFloat(window[len(window)-1].Close * 0.99)
+
+				// A revised version of the forming bar must be replaced,
+				// not folded in as an extra bar
+				revised := append([]alpaca.MockBar(nil), window...)
+				revised[len(revised)-1].Close *= 1.05
+				stream.Advance(revised)
+				assertSameSignal(t, n, stream.Signal("TEST", price),
+					strategy.AnalyzeFeatures(NewFeatures("TEST", revised), price))
+
+				stream.Advance(window)
+				got := stream.Signal("TEST", price)
+				assertSameSignal(t, n, got, strategy.AnalyzeFeatures(NewFeatures("TEST", window), price))
+				if got != nil {
+					signals++
+				}
+			}
+
+			if signals == 0 {
+				t.Fatal("no signals were compared")
+			}
+		})
+	}
+}
+
+func TestStreamKeysBarsByPeriod(t *testing.T) {
+	strategy := NewSMAStrategy(2, 3)
+	day := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+	bar := func(offset time.Duration, close float64) alpaca.MockBar {
+		return alpaca.MockBar{Timestamp: day.Add(offset), Open: close, High: close, Low: close, Close: close}
+	}
+
+	// The same session seen at different times of day is one bar
+	stream := NewStream(strategy, TimeframeDay)
+	stream.Advance([]alpaca.MockBar{bar(0, 10), bar(24*time.Hour, 10), bar(48*time.Hour+10*time.Hour, 10)})
+	stream.Advance([]alpaca.MockBar{bar(0, 10), bar(24*time.Hour, 10), bar(48*time.Hour+16*time.Hour, 10)})
+	stream.Advance([]alpaca.MockBar{bar(24*time.Hour, 10), bar(48*time.Hour+16*time.Hour, 10), bar(72*time.Hour, 13)})
+
+	bars := []alpaca.MockBar{bar(0, 10), bar(24*time.Hour, 10), bar(48*time.Hour, 10), bar(72*time.Hour, 13)}
+	price := decimal.NewFromInt(13)
+	want := strategy.AnalyzeFeatures(NewFeatures("TEST", bars), price)
+	if want == nil {
+		t.Fatal("expected the batch strategy to signal a crossover")
+	}
+	assertSameSignal(t, len(bars), stream.Signal("TEST", price), want)
+}
+
+func TestStreamRebuildsRegeneratedHistory(t *testing.T) {
+	incremental := []interface {
+		IncrementalStrategy
+		FeatureStrategy
+	}{
+		NewSMAStrategy(5, 20),
+		NewRSIStrategy(14, 30, 70),
+		NewMeanReversionStrategy(20, 2.0),
+	}
+
+	for _, strategy := range incremental {
+		t.Run(strategy.GetName(), func(t *testing.T) {
+			stream := NewStream(strategy, TimeframeDay)
+			stream.Advance(oscillatingBars(100, 1))
+
+			// The same days with different prices, as the mock market
+			// returns on every call, must not be folded onto the old state
+			for seed := int64(2); seed < 40; seed++ {
+				bars := oscillatingBars(100+int(seed%3), seed)
+				price := decimal.NewFromFloat(bars[len(bars)-1].Close)
+				stream.Advance(bars)
+				assertSameSignal(t, int(seed), stream.Signal("TEST", price),
+					strategy.AnalyzeFeatures(NewFeatures("TEST", bars), price))
+			}
+		})
+	}
+}
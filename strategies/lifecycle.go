@@ -0,0 +1,49 @@
+package strategies
+
+import (
+	"github.com/MunishMummadi/mock-trade-algorithm/alpaca"
+	"github.com/MunishMummadi/mock-trade-algorithm/models"
+)
+
+// The interfaces below are optional lifecycle hooks. The engine checks each
+// strategy for them, so existing strategies that only implement Strategy
+// keep working unchanged.
+
+// Initializer is implemented by strategies that need setup before the first
+// trading cycle. Init runs after any persisted state has been restored.
+type Initializer interface {
+	Init() error
+}
+
+// BarHandler receives every completed bar for each symbol the engine
+// trades, in time order. A call that fails or times out gets its bars again
+// on the next cycle; otherwise each bar is delivered once.
+type BarHandler interface {
+	OnBar(symbol string, bar alpaca.MockBar)
+}
+
+// OrderUpdateHandler is notified after each order the strategy's signal
+// contributed to is placed, with the order's resulting status
+type OrderUpdateHandler interface {
+	OnOrderUpdate(trade *models.Trade)
+}
+
+// FillHandler is notified when an order the strategy's signal contributed
+// to is filled
+type FillHandler interface {
+	OnFill(trade *models.Trade)
+}
+
+// Stateful is implemented by strategies that persist state across restarts.
+// SaveState is called after every trading cycle and on shutdown; RestoreState
+// is called once at startup with the last saved state, if any.
+type Stateful interface {
+	SaveState() ([]byte, error)
+	RestoreState(data []byte) error
+}
+
+// Closer is implemented by strategies holding resources, such as child
+// processes, that must be released when the engine stops
+type Closer interface {
+	Close() error
+}
@@ -0,0 +1,104 @@
+package strategies
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/MunishMummadi/mock-trade-algorithm/alpaca"
+	"github.com/MunishMummadi/mock-trade-algorithm/models"
+)
+
+// MACDStrategy implements a MACD signal-line crossover strategy
+type MACDStrategy struct {
+	BaseStrategy
+	fastPeriod   int
+	slowPeriod   int
+	signalPeriod int
+}
+
+// NewMACDStrategy creates a new MACD strategy
+func NewMACDStrategy(fastPeriod, slowPeriod, signalPeriod int) *MACDStrategy {
+	return &MACDStrategy{
+		BaseStrategy: BaseStrategy{
+			name:        "MACD Crossover",
+			description: "MACD signal-line crossover strategy",
+		},
+		fastPeriod:   fastPeriod,
+		slowPeriod:   slowPeriod,
+		signalPeriod: signalPeriod,
+	}
+}
+
+// Analyze implements the Strategy interface
+func (m *MACDStrategy) Analyze(symbol string, bars []alpaca.MockBar, currentPrice decimal.Decimal) *models.TradingSignal {
+	return m.AnalyzeFeatures(NewFeatures(symbol, bars), currentPrice)
+}
+
+// AnalyzeFeatures implements the FeatureStrategy interface
+func (m *MACDStrategy) AnalyzeFeatures(features *Features, currentPrice decimal.Decimal) *models.TradingSignal {
+	symbol := features.Symbol
+	if features.Len() < m.slowPeriod+m.signalPeriod {
+		return nil
+	}
+
+	// Calculate MACD histogram (MACD line minus signal line)
+	macdLine, signalLine, histogram := features.MACD(m.fastPeriod, m.slowPeriod, m.signalPeriod)
+	if len(histogram) < 2 {
+		return nil
+	}
+
+	currentHist := histogram[len(histogram)-1]
+	prevHist := histogram[len(histogram)-2]
+
+	// Determine signal
+	var signal, direction string
+
+	if prevHist <= 0 && currentHist > 0 {
+		// MACD crossed above its signal line
+		signal = "BUY"
+		direction = "above"
+	} else if prevHist >= 0 && currentHist < 0 {
+		// MACD crossed below its signal line
+		signal = "SELL"
+		direction = "below"
+	} else {
+		// No clear signal
+		return nil
+	}
+
+	// Strength grows with the histogram relative to its recent typical size
+	typical := 0.0
+	for _, h := range histogram {
+		typical += math.Abs(h)
+	}
+	typical /= float64(len(histogram))
+
+	strength := 0.5
+	if typical > 0 {
+		strength += 0.25 * math.Abs(currentHist) / typical
+	}
+	if strength > 1.0 {
+		strength = 1.0
+	}
+
+	return &models.TradingSignal{
+		Symbol:    symbol,
+		Signal:    signal,
+		Strength:  strength,
+		Price:     currentPrice,
+		Strategy:  m.GetName(),
+		CreatedAt: time.Now(),
+		Indicators: map[string]float64{
+			"macd":              macdLine[len(macdLine)-1],
+			"signal_line":       signalLine[len(signalLine)-1],
+			"histogram":         currentHist,
+			"prev_histogram":    prevHist,
+			"typical_histogram": typical,
+		},
+		Reason: fmt.Sprintf("MACD(%d,%d,%d) crossed %s its signal line: histogram %.4f (was %.4f)",
+			m.fastPeriod, m.slowPeriod, m.signalPeriod, direction, currentHist, prevHist),
+	}
+}
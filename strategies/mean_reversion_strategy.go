@@ -1,11 +1,14 @@
 package strategies
 
 import (
+	"fmt"
+	"math"
 	"time"
 
 	"github.com/shopspring/decimal"
 
 	"github.com/MunishMummadi/mock-trade-algorithm/alpaca"
+	"github.com/MunishMummadi/mock-trade-algorithm/indicators"
 	"github.com/MunishMummadi/mock-trade-algorithm/models"
 )
 
@@ -30,22 +33,47 @@ func NewMeanReversionStrategy(period int, standardDeviations float64) *MeanRever
 
 // Analyze implements the Strategy interface
 func (m *MeanReversionStrategy) Analyze(symbol string, bars []alpaca.MockBar, currentPrice decimal.Decimal) *models.TradingSignal {
-	if len(bars) < m.period {
+	return m.AnalyzeFeatures(NewFeatures(symbol, bars), currentPrice)
+}
+
+// AnalyzeFeatures implements the FeatureStrategy interface
+func (m *MeanReversionStrategy) AnalyzeFeatures(features *Features, currentPrice decimal.Decimal) *models.TradingSignal {
+	symbol := features.Symbol
+	if features.Len() < m.period {
 		return nil
 	}
 
-	prices := ExtractPrices(bars)
-
 	// Calculate Bollinger Bands
-	upper, middle, lower := CalculateBollingerBands(prices, m.period, m.standardDeviations)
+	upper, middle, lower := features.BollingerBands(m.period, m.standardDeviations)
 	if len(upper) == 0 || len(middle) == 0 || len(lower) == 0 {
 		return nil
 	}
 
 	// Get the latest values
-	currentUpper := upper[len(upper)-1]
-	currentMiddle := middle[len(middle)-1]
-	currentLower := lower[len(lower)-1]
+	currentUpper := decimal.NewFromFloat(upper[len(upper)-1])
+	currentMiddle := decimal.NewFromFloat(middle[len(middle)-1])
+	currentLower := decimal.NewFromFloat(lower[len(lower)-1])
+
+	recent := features.Bars
+	if len(recent) > 3 {
+		recent = recent[len(recent)-3:]
+	}
+
+	return m.signal(symbol, currentPrice, currentUpper, currentMiddle, currentLower, ExtractPrices(recent))
+}
+
+// NewState implements the IncrementalStrategy interface
+func (m *MeanReversionStrategy) NewState() IncrementalState {
+	return &meanReversionState{
+		strategy: m,
+		bands:    indicators.NewStreamingBollinger(m.period, m.standardDeviations),
+	}
+}
+
+// signal positions the current price within the bands. prices only needs to
+// hold the most recent closes used for the volatility check.
+func (m *MeanReversionStrategy) signal(symbol string, currentPrice decimal.Decimal,
+	currentUpper, currentMiddle, currentLower decimal.Decimal, prices []decimal.Decimal) *models.TradingSignal {
 
 	// Calculate where current price is within the bands
 	bandWidth := currentUpper.Sub(currentLower)
@@ -54,7 +82,7 @@ func (m *MeanReversionStrategy) Analyze(symbol string, bars []alpaca.MockBar, cu
 	}
 
 	// Determine signal based on position relative to bands
-	var signal string
+	var signal, reason string
 	var strength float64
 
 	if currentPrice.LessThan(currentLower) {
@@ -64,6 +92,7 @@ func (m *MeanReversionStrategy) Analyze(symbol string, bars []alpaca.MockBar, cu
 		distance := currentLower.Sub(currentPrice)
 		penetration := distance.Div(bandWidth).InexactFloat64()
 		strength = 0.6 + (penetration * 2) // Base strength + penetration factor
+		reason = fmt.Sprintf("price %s below lower band %.2f", currentPrice.StringFixed(2), currentLower.InexactFloat64())
 		if strength > 1.0 {
 			strength = 1.0
 		}
@@ -74,6 +103,7 @@ func (m *MeanReversionStrategy) Analyze(symbol string, bars []alpaca.MockBar, cu
 		distance := currentPrice.Sub(currentUpper)
 		penetration := distance.Div(bandWidth).InexactFloat64()
 		strength = 0.6 + (penetration * 2)
+		reason = fmt.Sprintf("price %s above upper band %.2f", currentPrice.StringFixed(2), currentUpper.InexactFloat64())
 		if strength > 1.0 {
 			strength = 1.0
 		}
@@ -88,35 +118,48 @@ func (m *MeanReversionStrategy) Analyze(symbol string, bars []alpaca.MockBar, cu
 			signal = "BUY"
 			distance := lowerThreshold.Sub(currentPrice)
 			strength = 0.3 + (distance.Div(bandWidth).InexactFloat64() * 0.5)
+			reason = fmt.Sprintf("price %s in lower part of bands, below %.2f", currentPrice.StringFixed(2), lowerThreshold.InexactFloat64())
 		} else if currentPrice.GreaterThan(upperThreshold) {
 			// Price is in upper 30% of band - weak sell signal
 			signal = "SELL"
 			distance := currentPrice.Sub(upperThreshold)
 			strength = 0.3 + (distance.Div(bandWidth).InexactFloat64() * 0.5)
+			reason = fmt.Sprintf("price %s in upper part of bands, above %.2f", currentPrice.StringFixed(2), upperThreshold.InexactFloat64())
 		} else {
 			// No clear signal
 			return nil
 		}
 	}
 
+	indicatorValues := map[string]float64{
+		"upper_band":  currentUpper.InexactFloat64(),
+		"middle_band": currentMiddle.InexactFloat64(),
+		"lower_band":  currentLower.InexactFloat64(),
+		"price":       currentPrice.InexactFloat64(),
+	}
+
 	// Additional validation: check recent price movement
 	if len(prices) >= 3 {
 		recentPrices := prices[len(prices)-3:]
 		volatility := m.calculateVolatility(recentPrices)
+		indicatorValues["volatility"] = volatility
 
 		// Reduce strength if volatility is too high (risky conditions)
 		if volatility > 0.05 { // 5% volatility threshold
 			strength *= 0.7
+			reason += fmt.Sprintf("; strength reduced for %.1f%% volatility", volatility*100)
 		}
 	}
 
 	return &models.TradingSignal{
-		Symbol:    symbol,
-		Signal:    signal,
-		Strength:  strength,
-		Price:     currentPrice,
-		Strategy:  m.GetName(),
-		CreatedAt: time.Now(),
+		Symbol:     symbol,
+		Signal:     signal,
+		Strength:   strength,
+		Price:      currentPrice,
+		Strategy:   m.GetName(),
+		CreatedAt:  time.Now(),
+		Indicators: indicatorValues,
+		Reason:     reason,
 	}
 }
 
@@ -126,12 +169,44 @@ func (m *MeanReversionStrategy) calculateVolatility(prices []decimal.Decimal) fl
 		return 0.0
 	}
 
-	var totalVariance decimal.Decimal
+	totalVariance := 0.0
 	for i := 1; i < len(prices); i++ {
-		change := prices[i].Sub(prices[i-1]).Div(prices[i-1])
-		totalVariance = totalVariance.Add(change.Mul(change))
+		change := prices[i].Sub(prices[i-1]).Div(prices[i-1]).InexactFloat64()
+		totalVariance += change * change
 	}
 
-	variance := totalVariance.Div(decimal.NewFromInt(int64(len(prices) - 1)))
-	return variance.Pow(decimal.NewFromFloat(0.5)).InexactFloat64()
+	return math.Sqrt(totalVariance / float64(len(prices)-1))
+}
+
+// meanReversionState keeps rolling Bollinger Bands and the last few closes
+type meanReversionState struct {
+	strategy *MeanReversionStrategy
+	bands    *indicators.StreamingBollinger
+	recent   []decimal.Decimal
+}
+
+func (st *meanReversionState) Update(bar alpaca.MockBar) {
+	st.bands.Update(bar.Close)
+	st.recent = append(st.recent, decimal.NewFromFloat(bar.Close))
+	if len(st.recent) > 3 {
+		st.recent = st.recent[len(st.recent)-3:]
+	}
+}
+
+func (st *meanReversionState) Signal(symbol string, currentPrice decimal.Decimal) *models.TradingSignal {
+	if !st.bands.Ready() {
+		return nil
+	}
+
+	upper, middle, lower := st.bands.Bands()
+	return st.strategy.signal(symbol, currentPrice, decimal.NewFromFloat(upper),
+		decimal.NewFromFloat(middle), decimal.NewFromFloat(lower), st.recent)
+}
+
+func (st *meanReversionState) Clone() IncrementalState {
+	return &meanReversionState{
+		strategy: st.strategy,
+		bands:    st.bands.Clone(),
+		recent:   append([]decimal.Decimal(nil), st.recent...),
+	}
 }
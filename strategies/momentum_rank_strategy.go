@@ -0,0 +1,102 @@
+package strategies
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/MunishMummadi/mock-trade-algorithm/models"
+)
+
+// MomentumRankStrategy ranks the whole watchlist by trailing return, buying
+// the strongest symbols and selling the weakest
+type MomentumRankStrategy struct {
+	BaseStrategy
+	lookback int
+	topN     int
+}
+
+// NewMomentumRankStrategy creates a new cross-sectional momentum strategy
+func NewMomentumRankStrategy(lookback, topN int) *MomentumRankStrategy {
+	return &MomentumRankStrategy{
+		BaseStrategy: BaseStrategy{
+			name:        "Momentum Rank",
+			description: "Cross-sectional momentum ranking across the watchlist",
+		},
+		lookback: lookback,
+		topN:     topN,
+	}
+}
+
+// AnalyzeUniverse implements the UniverseStrategy interface
+func (m *MomentumRankStrategy) AnalyzeUniverse(universe map[string]*Features, prices map[string]decimal.Decimal) []*models.TradingSignal {
+	type ranked struct {
+		symbol   string
+		momentum float64
+	}
+
+	var ranking []ranked
+	for _, symbol := range Universe(universe) {
+		if _, priced := prices[symbol]; !priced {
+			continue
+		}
+		if momentum, ok := trailingReturn(universe[symbol], m.lookback); ok {
+			ranking = append(ranking, ranked{symbol: symbol, momentum: momentum})
+		}
+	}
+
+	// Need a top and a bottom group that don't overlap
+	if len(ranking) < 2*m.topN || m.topN <= 0 {
+		return nil
+	}
+
+	sort.SliceStable(ranking, func(i, j int) bool {
+		return ranking[i].momentum > ranking[j].momentum
+	})
+
+	var signals []*models.TradingSignal
+	for i := 0; i < m.topN; i++ {
+		// Strength falls off with rank within each group
+		strength := 1.0 - 0.3*float64(i)/float64(m.topN)
+
+		leader := ranking[i]
+		if leader.momentum > 0 {
+			signals = append(signals, m.signal(leader.symbol, "BUY", strength, prices[leader.symbol],
+				leader.momentum, i+1, len(ranking)))
+		}
+
+		laggard := ranking[len(ranking)-1-i]
+		if laggard.momentum < 0 {
+			signals = append(signals, m.signal(laggard.symbol, "SELL", strength, prices[laggard.symbol],
+				laggard.momentum, len(ranking)-i, len(ranking)))
+		}
+	}
+
+	return signals
+}
+
+func (m *MomentumRankStrategy) signal(symbol, side string, strength float64, price decimal.Decimal,
+	momentum float64, rank, ranked int) *models.TradingSignal {
+
+	signal := &models.TradingSignal{
+		Symbol:    symbol,
+		Signal:    side,
+		Strength:  strength,
+		Price:     price,
+		Strategy:  m.GetName(),
+		CreatedAt: time.Now(),
+		Indicators: map[string]float64{
+			"momentum": momentum,
+			"rank":     float64(rank),
+			"ranked":   float64(ranked),
+		},
+		Reason: fmt.Sprintf("ranked %d of %d by %d-bar return %+.2f%%", rank, ranked, m.lookback, momentum*100),
+	}
+	if side == "BUY" {
+		// Spread the allocation equally across the leaders
+		signal.TargetWeight = 1.0 / float64(m.topN)
+	}
+	return signal
+}
@@ -0,0 +1,275 @@
+package strategies
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/MunishMummadi/mock-trade-algorithm/alpaca"
+	"github.com/MunishMummadi/mock-trade-algorithm/models"
+)
+
+// Trend is the direction a trend filter reads on the higher timeframe
+type Trend int
+
+const (
+	TrendNone Trend = iota // no clear trend, or not enough bars to tell
+	TrendUp
+	TrendDown
+)
+
+func (t Trend) String() string {
+	switch t {
+	case TrendUp:
+		return "up"
+	case TrendDown:
+		return "down"
+	default:
+		return "none"
+	}
+}
+
+// TrendFilter reads the trend of a higher-timeframe frame. It returns the
+// indicator value it judged by and a description for signal reasons.
+type TrendFilter interface {
+	Trend(features *Features) (Trend, float64, string)
+}
+
+// SMASlopeFilter reads the trend from the slope of a moving average: up if
+// it rose over the last Lookback bars, down if it fell
+type SMASlopeFilter struct {
+	Period   int
+	Lookback int
+}
+
+// Trend implements TrendFilter; the value is the fractional change of the SMA
+func (f SMASlopeFilter) Trend(features *Features) (Trend, float64, string) {
+	sma := features.SMA(f.Period)
+	if len(sma) <= f.Lookback {
+		return TrendNone, 0, ""
+	}
+
+	last := sma[len(sma)-1]
+	before := sma[len(sma)-1-f.Lookback]
+	if before == 0 {
+		return TrendNone, 0, ""
+	}
+
+	slope := last/before - 1
+	trend := TrendNone
+	switch {
+	case slope > 0:
+		trend = TrendUp
+	case slope < 0:
+		trend = TrendDown
+	}
+	return trend, slope, fmt.Sprintf("SMA(%d) slope %+.2f%% over %d bars", f.Period, slope*100, f.Lookback)
+}
+
+// ADXFilter reads the trend from the directional movement index: a trend
+// exists when ADX is at least Threshold, pointing the way of the larger DI
+type ADXFilter struct {
+	Period    int
+	Threshold float64
+}
+
+// Trend implements TrendFilter; the value is the ADX
+func (f ADXFilter) Trend(features *Features) (Trend, float64, string) {
+	adx, plusDI, minusDI := features.ADX(f.Period)
+	if len(adx) == 0 {
+		return TrendNone, 0, ""
+	}
+
+	strength := adx[len(adx)-1]
+	plus := plusDI[len(plusDI)-1]
+	minus := minusDI[len(minusDI)-1]
+
+	trend := TrendNone
+	switch {
+	case strength < f.Threshold:
+	case plus > minus:
+		trend = TrendUp
+	case minus > plus:
+		trend = TrendDown
+	}
+	return trend, strength, fmt.Sprintf("ADX(%d) %.1f with +DI %.1f, -DI %.1f", f.Period, strength, plus, minus)
+}
+
+// ParseTrendFilter parses "sma_slope:period[:lookback]" or
+// "adx:period[:threshold]". The lookback defaults to 1 bar and the
+// threshold to 25.
+func ParseTrendFilter(spec string) (TrendFilter, error) {
+	fields := strings.Split(strings.TrimSpace(spec), ":")
+	if len(fields) < 2 || len(fields) > 3 {
+		return nil, fmt.Errorf("invalid trend filter %q (use sma_slope:period[:lookback] or adx:period[:threshold])", spec)
+	}
+
+	period, err := strconv.Atoi(fields[1])
+	if err != nil || period <= 0 {
+		return nil, fmt.Errorf("invalid period in trend filter %q", spec)
+	}
+
+	switch strings.ToLower(fields[0]) {
+	case "sma_slope":
+		lookback := 1
+		if len(fields) == 3 {
+			if lookback, err = strconv.Atoi(fields[2]); err != nil || lookback <= 0 {
+				return nil, fmt.Errorf("invalid lookback in trend filter %q", spec)
+			}
+		}
+		return SMASlopeFilter{Period: period, Lookback: lookback}, nil
+	case "adx":
+		threshold := 25.0
+		if len(fields) == 3 {
+			if threshold, err = strconv.ParseFloat(fields[2], 64); err != nil || threshold < 0 || threshold > 100 {
+				return nil, fmt.Errorf("invalid threshold in trend filter %q", spec)
+			}
+		}
+		return ADXFilter{Period: period, Threshold: threshold}, nil
+	default:
+		return nil, fmt.Errorf("unknown trend filter %q (use sma_slope or adx)", fields[0])
+	}
+}
+
+// MultiTimeframeStrategy runs an inner strategy on a lower timeframe and
+// passes its signals on only when a trend filter on a higher timeframe
+// agrees: BUY needs an up trend and SELL a down trend. Both timeframes are
+// resampled from the bars the engine supplies.
+type MultiTimeframeStrategy struct {
+	BaseStrategy
+	inner  Strategy
+	lower  Timeframe
+	higher Timeframe
+	filter TrendFilter
+}
+
+// NewMultiTimeframeStrategy wraps inner. The higher timeframe may not be
+// shorter than the lower one.
+func NewMultiTimeframeStrategy(inner Strategy, lower, higher Timeframe, filter TrendFilter) (*MultiTimeframeStrategy, error) {
+	if higher.Length() < lower.Length() {
+		return nil, fmt.Errorf("higher timeframe %s is shorter than lower timeframe %s", higher, lower)
+	}
+
+	return &MultiTimeframeStrategy{
+		BaseStrategy: BaseStrategy{
+			name:        fmt.Sprintf("%s %s/%s", inner.GetName(), lower, higher),
+			description: fmt.Sprintf("%s on %s bars confirmed by the %s trend", inner.GetName(), lower, higher),
+		},
+		inner:  inner,
+		lower:  lower,
+		higher: higher,
+		filter: filter,
+	}, nil
+}
+
+// Analyze implements the Strategy interface
+func (m *MultiTimeframeStrategy) Analyze(symbol string, bars []alpaca.MockBar, currentPrice decimal.Decimal) *models.TradingSignal {
+	return m.AnalyzeFeatures(NewFeatures(symbol, bars), currentPrice)
+}
+
+// AnalyzeFeatures implements the FeatureStrategy interface
+func (m *MultiTimeframeStrategy) AnalyzeFeatures(features *Features, currentPrice decimal.Decimal) *models.TradingSignal {
+	lower := resampleFeatures(features, m.lower)
+
+	var signal *models.TradingSignal
+	if inner, ok := m.inner.(FeatureStrategy); ok {
+		signal = inner.AnalyzeFeatures(lower, currentPrice)
+	} else {
+		signal = m.inner.Analyze(lower.Symbol, lower.Bars, currentPrice)
+	}
+	if signal == nil || (signal.Signal != "BUY" && signal.Signal != "SELL") {
+		return nil
+	}
+
+	trend, value, description := m.filter.Trend(resampleFeatures(features, m.higher))
+	if (signal.Signal == "BUY" && trend != TrendUp) || (signal.Signal == "SELL" && trend != TrendDown) {
+		return nil
+	}
+
+	indicators := make(map[string]float64, len(signal.Indicators)+2)
+	for name, v := range signal.Indicators {
+		indicators[name] = v
+	}
+	indicators["htf_trend"] = 1
+	if trend == TrendDown {
+		indicators["htf_trend"] = -1
+	}
+	indicators["htf_filter"] = value
+
+	confirmed := *signal
+	confirmed.Strategy = m.GetName()
+	confirmed.CreatedAt = time.Now()
+	confirmed.Indicators = indicators
+	confirmed.Reason = fmt.Sprintf("%s; %s trend %s: %s", signal.Reason, m.higher, trend, description)
+	return &confirmed
+}
+
+// resampleFeatures returns a frame of the symbol's bars in the timeframe,
+// reusing the engine's frame when no resampling is needed
+func resampleFeatures(features *Features, tf Timeframe) *Features {
+	bars := Resample(features.Bars, tf)
+	if len(bars) == len(features.Bars) {
+		return features
+	}
+	return NewFeatures(features.Symbol, bars)
+}
+
+// MultiTimeframeSpec configures one multi-timeframe wrapper
+type MultiTimeframeSpec struct {
+	Strategy string // name of the inner strategy
+	Lower    Timeframe
+	Higher   Timeframe
+	Filter   TrendFilter
+}
+
+// ParseMultiTimeframeSpecs parses semicolon separated wrapper specs, each a
+// comma separated list of strategy=name, lower=timeframe,
+// higher=timeframe and filter=trend filter. Lower defaults to 1Day, higher
+// to 1Week and the filter to sma_slope:10.
+func ParseMultiTimeframeSpecs(value string) ([]MultiTimeframeSpec, error) {
+	var specs []MultiTimeframeSpec
+	for _, entry := range strings.Split(value, ";") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+
+		spec := MultiTimeframeSpec{
+			Lower:  TimeframeDay,
+			Higher: TimeframeWeek,
+			Filter: SMASlopeFilter{Period: 10, Lookback: 1},
+		}
+		for _, part := range strings.Split(entry, ",") {
+			key, val, found := strings.Cut(part, "=")
+			key, val = strings.ToLower(strings.TrimSpace(key)), strings.TrimSpace(val)
+			if !found || key == "" || val == "" {
+				return nil, fmt.Errorf("expected key=value, got %q", strings.TrimSpace(part))
+			}
+
+			var err error
+			switch key {
+			case "strategy":
+				spec.Strategy = val
+			case "lower":
+				spec.Lower, err = ParseTimeframe(val)
+			case "higher":
+				spec.Higher, err = ParseTimeframe(val)
+			case "filter":
+				spec.Filter, err = ParseTrendFilter(val)
+			default:
+				err = fmt.Errorf("unknown key %q", key)
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if spec.Strategy == "" {
+			return nil, fmt.Errorf("missing strategy in %q", strings.TrimSpace(entry))
+		}
+		specs = append(specs, spec)
+	}
+	return specs, nil
+}
@@ -0,0 +1,334 @@
+package strategies
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/MunishMummadi/mock-trade-algorithm/indicators"
+	"github.com/MunishMummadi/mock-trade-algorithm/models"
+)
+
+// PairsStrategy is a market-neutral statistical arbitrage strategy. It tests
+// candidate pairs for cointegration (Engle-Granger on log prices), trades the
+// spread when its z-score stretches past entryZ and closes both legs together
+// when it reverts inside exitZ.
+type PairsStrategy struct {
+	BaseStrategy
+	lookback int     // bars used for the cointegration test
+	zWindow  int     // bars used for the spread z-score
+	entryZ   float64 // open when |z| exceeds this
+	exitZ    float64 // close when |z| falls below this
+	pairable func(a, b string) bool
+
+	pairs map[string]*openPair // pair key -> open position
+}
+
+// openPair is a pair position the strategy has entered. Quantities are
+// signed (negative for the short leg) and kept up to date from fills. Once
+// an exit is signalled the pair is exiting until both legs are flat.
+type openPair struct {
+	Y          string                     `json:"y"`
+	X          string                     `json:"x"`
+	Alpha      float64                    `json:"alpha"`
+	HedgeRatio float64                    `json:"hedge_ratio"`
+	EntryZ     float64                    `json:"entry_z"`
+	Quantities map[string]decimal.Decimal `json:"quantities"`
+	OpenedAt   time.Time                  `json:"opened_at"`
+	Exiting    bool                       `json:"exiting"`
+}
+
+// NewPairsStrategy creates a new pairs trading strategy; pairable decides
+// which symbol pairs are worth testing, e.g. same sector and shortable
+func NewPairsStrategy(lookback, zWindow int, entryZ, exitZ float64, pairable func(a, b string) bool) *PairsStrategy {
+	return &PairsStrategy{
+		BaseStrategy: BaseStrategy{
+			name:        "Pairs Trading",
+			description: "Cointegrated pairs spread trading with paired long/short legs",
+		},
+		lookback: lookback,
+		zWindow:  zWindow,
+		entryZ:   entryZ,
+		exitZ:    exitZ,
+		pairable: pairable,
+		pairs:    make(map[string]*openPair),
+	}
+}
+
+// AnalyzeUniverse implements the UniverseStrategy interface
+func (p *PairsStrategy) AnalyzeUniverse(universe map[string]*Features, prices map[string]decimal.Decimal) []*models.TradingSignal {
+	var signals []*models.TradingSignal
+	inUse := make(map[string]bool)
+
+	// Manage open pairs first; each symbol belongs to at most one pair
+	for _, key := range p.pairKeys() {
+		pair := p.pairs[key]
+		if pair.flat() {
+			// The entry never filled
+			delete(p.pairs, key)
+			continue
+		}
+		inUse[pair.Y], inUse[pair.X] = true, true
+
+		y, x := universe[pair.Y], universe[pair.X]
+		if y == nil || x == nil {
+			continue
+		}
+		logY, logX := alignedLogCloses(y, x, p.lookback)
+		spread := make([]float64, len(logY))
+		for i := range logY {
+			spread[i] = logY[i] - pair.Alpha - pair.HedgeRatio*logX[i]
+		}
+		z, ok := indicators.ZScore(spread, p.zWindow)
+		if !ok {
+			continue
+		}
+
+		// Exit on reversion, or when the spread keeps running away. The
+		// pair is only dropped in OnFill once both legs are flat, so an exit
+		// that doesn't fill is sent again next cycle.
+		retry := pair.Exiting
+		if retry || math.Abs(z) < p.exitZ || math.Abs(z) > 2*p.entryZ {
+			pair.Exiting = true
+			signals = append(signals, p.exitSignal(pair, z, retry, prices))
+		}
+	}
+
+	// Rank untested candidates by ADF statistic so the most strongly
+	// cointegrated pairs get first claim on their symbols
+	type candidate struct {
+		y, x  string
+		coint *indicators.Cointegration
+		z     float64
+	}
+	var candidates []candidate
+
+	symbols := Universe(universe)
+	for i, a := range symbols {
+		for _, b := range symbols[i+1:] {
+			if inUse[a] || inUse[b] || !p.pairable(a, b) {
+				continue
+			}
+			if _, priced := prices[a]; !priced {
+				continue
+			}
+			if _, priced := prices[b]; !priced {
+				continue
+			}
+
+			logY, logX := alignedLogCloses(universe[a], universe[b], p.lookback)
+			coint := indicators.EngleGranger(logY, logX)
+			if coint == nil || !coint.Cointegrated() || coint.HedgeRatio <= 0 {
+				continue
+			}
+			z, ok := indicators.ZScore(coint.Spread, p.zWindow)
+			if !ok || math.Abs(z) < p.entryZ {
+				continue
+			}
+			candidates = append(candidates, candidate{y: a, x: b, coint: coint, z: z})
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].coint.ADF < candidates[j].coint.ADF
+	})
+
+	for _, c := range candidates {
+		if inUse[c.y] || inUse[c.x] {
+			continue
+		}
+		inUse[c.y], inUse[c.x] = true, true
+
+		pair := &openPair{
+			Y:          c.y,
+			X:          c.x,
+			Alpha:      c.coint.Alpha,
+			HedgeRatio: c.coint.HedgeRatio,
+			EntryZ:     c.z,
+			Quantities: map[string]decimal.Decimal{c.y: decimal.Zero, c.x: decimal.Zero},
+			OpenedAt:   time.Now(),
+		}
+		p.pairs[pairKey(c.y, c.x)] = pair
+		signals = append(signals, p.entrySignal(pair, c.coint.ADF, prices))
+	}
+
+	return signals
+}
+
+// entrySignal sells the rich leg and buys the cheap one, splitting the
+// notional by hedge ratio so the legs offset each other
+func (p *PairsStrategy) entrySignal(pair *openPair, adf float64, prices map[string]decimal.Decimal) *models.TradingSignal {
+	ySide, xSide := models.OrderSideBuy, models.OrderSideSell
+	if pair.EntryZ > 0 {
+		// Spread is above its mean: Y is rich relative to X
+		ySide, xSide = models.OrderSideSell, models.OrderSideBuy
+	}
+
+	total := 1 + pair.HedgeRatio
+	strength := math.Min(1.0, 0.6+0.2*(math.Abs(pair.EntryZ)-p.entryZ))
+
+	signal := p.signal(pair.Y, ySide, strength, prices, []models.SignalLeg{
+		{Symbol: pair.Y, Side: ySide, Weight: 1 / total},
+		{Symbol: pair.X, Side: xSide, Weight: pair.HedgeRatio / total},
+	})
+	signal.Indicators = map[string]float64{
+		"z_score":     pair.EntryZ,
+		"hedge_ratio": pair.HedgeRatio,
+		"adf":         adf,
+	}
+	signal.Reason = fmt.Sprintf("%s/%s spread z-score %+.2f beyond %.1f (hedge ratio %.3f, ADF %.2f)",
+		pair.Y, pair.X, pair.EntryZ, p.entryZ, pair.HedgeRatio, adf)
+	return signal
+}
+
+// exitSignal closes both legs with the quantities still open; retry marks
+// an exit sent again because an earlier one left a leg open
+func (p *PairsStrategy) exitSignal(pair *openPair, z float64, retry bool, prices map[string]decimal.Decimal) *models.TradingSignal {
+	var legs []models.SignalLeg
+	for _, symbol := range []string{pair.Y, pair.X} {
+		quantity := pair.Quantities[symbol]
+		side := models.OrderSideSell
+		if quantity.IsNegative() {
+			side = models.OrderSideBuy
+		}
+		if !quantity.IsZero() {
+			legs = append(legs, models.SignalLeg{Symbol: symbol, Side: side, Quantity: quantity.Abs()})
+		}
+	}
+
+	// Exits are not optional, so they always carry full strength
+	signal := p.signal(legs[0].Symbol, legs[0].Side, 1.0, prices, legs)
+	signal.Indicators = map[string]float64{
+		"z_score":       z,
+		"entry_z_score": pair.EntryZ,
+		"hedge_ratio":   pair.HedgeRatio,
+	}
+	switch {
+	case retry:
+		signal.Reason = fmt.Sprintf("%s/%s exit still open, closing the remaining legs at z-score %+.2f", pair.Y, pair.X, z)
+	case math.Abs(z) < p.exitZ:
+		signal.Reason = fmt.Sprintf("%s/%s spread reverted to z-score %+.2f", pair.Y, pair.X, z)
+	default:
+		signal.Reason = fmt.Sprintf("%s/%s spread diverged to z-score %+.2f, stopping out", pair.Y, pair.X, z)
+	}
+	return signal
+}
+
+func (p *PairsStrategy) signal(symbol string, side models.OrderSide, strength float64,
+	prices map[string]decimal.Decimal, legs []models.SignalLeg) *models.TradingSignal {
+
+	signal := "BUY"
+	if side == models.OrderSideSell {
+		signal = "SELL"
+	}
+	return &models.TradingSignal{
+		Symbol:    symbol,
+		Signal:    signal,
+		Strength:  strength,
+		Price:     prices[symbol],
+		Strategy:  p.GetName(),
+		CreatedAt: time.Now(),
+		Legs:      legs,
+	}
+}
+
+// OnFill implements the FillHandler interface, tracking the filled
+// quantity of each open pair leg
+func (p *PairsStrategy) OnFill(trade *models.Trade) {
+	for key, pair := range p.pairs {
+		quantity, exists := pair.Quantities[trade.Symbol]
+		if !exists {
+			continue
+		}
+		if trade.Side == models.OrderSideBuy {
+			pair.Quantities[trade.Symbol] = quantity.Add(trade.Quantity)
+		} else {
+			pair.Quantities[trade.Symbol] = quantity.Sub(trade.Quantity)
+		}
+		if pair.flat() {
+			// Both legs were unwound
+			delete(p.pairs, key)
+		}
+		return
+	}
+}
+
+// HeldSymbols implements the PositionHolder interface
+func (p *PairsStrategy) HeldSymbols() []string {
+	var symbols []string
+	for _, key := range p.pairKeys() {
+		pair := p.pairs[key]
+		symbols = append(symbols, pair.Y, pair.X)
+	}
+	return symbols
+}
+
+// SaveState implements the Stateful interface
+func (p *PairsStrategy) SaveState() ([]byte, error) {
+	return json.Marshal(p.pairs)
+}
+
+// RestoreState implements the Stateful interface
+func (p *PairsStrategy) RestoreState(data []byte) error {
+	pairs := make(map[string]*openPair)
+	if err := json.Unmarshal(data, &pairs); err != nil {
+		return fmt.Errorf("failed to decode open pairs: %w", err)
+	}
+	p.pairs = pairs
+	return nil
+}
+
+func (p *PairsStrategy) pairKeys() []string {
+	keys := make([]string, 0, len(p.pairs))
+	for key := range p.pairs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (op *openPair) flat() bool {
+	for _, quantity := range op.Quantities {
+		if !quantity.IsZero() {
+			return false
+		}
+	}
+	return true
+}
+
+func pairKey(y, x string) string {
+	return y + "|" + x
+}
+
+// alignedLogCloses returns the log closes of two symbols on the days both
+// traded, limited to the last lookback of them. Equities and crypto have
+// different calendars, so bars can't be matched by index.
+func alignedLogCloses(y, x *Features, lookback int) ([]float64, []float64) {
+	xCloses := make(map[time.Time]float64, x.Len())
+	for _, bar := range x.Bars {
+		xCloses[sessionDay(bar.Timestamp)] = bar.Close
+	}
+
+	var logY, logX []float64
+	for _, bar := range y.Bars {
+		xClose, exists := xCloses[sessionDay(bar.Timestamp)]
+		if !exists || bar.Close <= 0 || xClose <= 0 {
+			continue
+		}
+		logY = append(logY, math.Log(bar.Close))
+		logX = append(logX, math.Log(xClose))
+	}
+
+	if len(logY) > lookback {
+		logY, logX = logY[len(logY)-lookback:], logX[len(logX)-lookback:]
+	}
+	return logY, logX
+}
+
+func sessionDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
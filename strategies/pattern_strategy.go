@@ -0,0 +1,153 @@
+package strategies
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/MunishMummadi/mock-trade-algorithm/alpaca"
+	"github.com/MunishMummadi/mock-trade-algorithm/models"
+	"github.com/MunishMummadi/mock-trade-algorithm/patterns"
+)
+
+// PatternStrategy trades candlestick reversal patterns completed by the
+// latest bar. A pattern counts only after the move it reverses: bullish
+// patterns need prices to have fallen by at least minMove over the
+// trendPeriod bars before it, bearish ones to have risen. It is confirmed
+// when the live price moves past the pattern's close in its direction.
+// Neutral patterns such as doji and inside bars are not traded on their own.
+type PatternStrategy struct {
+	BaseStrategy
+	tolerances  patterns.Tolerances
+	trendPeriod int
+	minMove     float64
+	volumeRatio float64 // pattern volume over the prior average that adds conviction
+}
+
+// NewPatternStrategy creates a new candlestick pattern strategy
+func NewPatternStrategy(tolerances patterns.Tolerances, trendPeriod int, minMove, volumeRatio float64) *PatternStrategy {
+	return &PatternStrategy{
+		BaseStrategy: BaseStrategy{
+			name:        "Candlestick Patterns",
+			description: "Candlestick reversal patterns confirmed by price, in trend and volume context",
+		},
+		tolerances:  tolerances,
+		trendPeriod: trendPeriod,
+		minMove:     minMove,
+		volumeRatio: volumeRatio,
+	}
+}
+
+// Analyze implements the Strategy interface
+func (p *PatternStrategy) Analyze(symbol string, bars []alpaca.MockBar, currentPrice decimal.Decimal) *models.TradingSignal {
+	return p.AnalyzeFeatures(NewFeatures(symbol, bars), currentPrice)
+}
+
+// AnalyzeFeatures implements the FeatureStrategy interface
+func (p *PatternStrategy) AnalyzeFeatures(features *Features, currentPrice decimal.Decimal) *models.TradingSignal {
+	// Room for the longest pattern and the trend before it
+	if features.Len() < p.trendPeriod+4 {
+		return nil
+	}
+
+	bars := features.Bars
+	closes := features.Closes()
+	last := len(bars) - 1
+	lastClose := closes[last]
+	price := currentPrice.InexactFloat64()
+
+	// Keep the patterns that reverse the prior move and are confirmed by price
+	var confirmed []patterns.Match
+	var moves []float64
+	for _, match := range patterns.At(bars, last, p.tolerances) {
+		before := match.Start - 1
+		if before-p.trendPeriod < 0 || closes[before-p.trendPeriod] <= 0 {
+			continue
+		}
+		move := closes[before]/closes[before-p.trendPeriod] - 1
+
+		switch {
+		case match.Direction == patterns.Bullish && move <= -p.minMove && price > lastClose:
+		case match.Direction == patterns.Bearish && move >= p.minMove && price < lastClose:
+		default:
+			continue
+		}
+
+		// Patterns disagreeing on direction cancel out
+		if len(confirmed) > 0 && confirmed[0].Direction != match.Direction {
+			return nil
+		}
+		confirmed = append(confirmed, match)
+		moves = append(moves, move)
+	}
+	if len(confirmed) == 0 {
+		return nil
+	}
+
+	// Context is taken from the longest pattern
+	longest, priorMove := confirmed[0], moves[0]
+	names := make([]string, len(confirmed))
+	for i, match := range confirmed {
+		names[i] = match.Pattern
+		if match.End-match.Start > longest.End-longest.Start {
+			longest, priorMove = match, moves[i]
+		}
+	}
+	volumeRatio := p.patternVolumeRatio(features.Volumes(), longest)
+
+	// Every further pattern and above-average volume add conviction
+	strength := 0.5 + 0.1*math.Min(float64(len(confirmed)-1), 2)
+	if volumeRatio >= p.volumeRatio {
+		strength += 0.2
+	}
+
+	signal := "BUY"
+	comparison := "above"
+	if longest.Direction == patterns.Bearish {
+		signal = "SELL"
+		comparison = "below"
+	}
+
+	return &models.TradingSignal{
+		Symbol:    features.Symbol,
+		Signal:    signal,
+		Strength:  math.Min(strength, 1.0),
+		Price:     currentPrice,
+		Strategy:  p.GetName(),
+		CreatedAt: time.Now(),
+		Indicators: map[string]float64{
+			"patterns":      float64(len(confirmed)),
+			"prior_move":    priorMove,
+			"volume_ratio":  volumeRatio,
+			"pattern_close": lastClose,
+			"price":         price,
+		},
+		Reason: fmt.Sprintf("%s after a %+.1f%% move over %d bars, confirmed by price %.2f %s close %.2f on %.1fx average volume",
+			strings.Join(names, ", "), priorMove*100, p.trendPeriod, price, comparison, lastClose, volumeRatio),
+	}
+}
+
+// patternVolumeRatio compares the pattern's average volume with the average
+// over the trendPeriod bars before it
+func (p *PatternStrategy) patternVolumeRatio(volumes []float64, match patterns.Match) float64 {
+	var during, before float64
+	for i := match.Start; i <= match.End; i++ {
+		during += volumes[i]
+	}
+	during /= float64(match.End - match.Start + 1)
+
+	start := match.Start - p.trendPeriod
+	if start < 0 {
+		start = 0
+	}
+	for i := start; i < match.Start; i++ {
+		before += volumes[i]
+	}
+	if match.Start == start || before == 0 {
+		return 0
+	}
+	return during / (before / float64(match.Start-start))
+}
@@ -1,11 +1,13 @@
 package strategies
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/shopspring/decimal"
 
 	"github.com/MunishMummadi/mock-trade-algorithm/alpaca"
+	"github.com/MunishMummadi/mock-trade-algorithm/indicators"
 	"github.com/MunishMummadi/mock-trade-algorithm/models"
 )
 
@@ -32,29 +34,45 @@ func NewRSIStrategy(period int, oversoldLevel, overboughtLevel float64) *RSIStra
 
 // Analyze implements the Strategy interface
 func (r *RSIStrategy) Analyze(symbol string, bars []alpaca.MockBar, currentPrice decimal.Decimal) *models.TradingSignal {
-	if len(bars) < r.period+1 {
+	return r.AnalyzeFeatures(NewFeatures(symbol, bars), currentPrice)
+}
+
+// AnalyzeFeatures implements the FeatureStrategy interface
+func (r *RSIStrategy) AnalyzeFeatures(features *Features, currentPrice decimal.Decimal) *models.TradingSignal {
+	symbol := features.Symbol
+	if features.Len() < r.period+1 {
 		return nil
 	}
 
-	prices := ExtractPrices(bars)
-
 	// Calculate RSI
-	rsi := CalculateRSI(prices, r.period)
+	rsi := features.RSI(r.period)
 	if len(rsi) < 2 {
 		return nil
 	}
 
 	// Get current and previous RSI values
-	currentRSI := rsi[len(rsi)-1].InexactFloat64()
-	prevRSI := rsi[len(rsi)-2].InexactFloat64()
+	currentRSI := rsi[len(rsi)-1]
+	prevRSI := rsi[len(rsi)-2]
+
+	return r.signal(symbol, currentPrice, prevRSI, currentRSI)
+}
 
+// NewState implements the IncrementalStrategy interface
+func (r *RSIStrategy) NewState() IncrementalState {
+	return &rsiState{strategy: r, rsi: indicators.NewStreamingRSI(r.period)}
+}
+
+// signal turns the previous and current RSI values into a signal
+func (r *RSIStrategy) signal(symbol string, currentPrice decimal.Decimal, prevRSI, currentRSI float64) *models.TradingSignal {
 	// Determine signal
-	var signal string
+	var signal, reason string
 	var strength float64
 
 	if prevRSI > r.oversoldLevel && currentRSI <= r.oversoldLevel {
 		// RSI crossed below oversold level - potential buy signal
 		signal = "BUY"
+		reason = fmt.Sprintf("RSI(%d) crossed below oversold %.0f: %.1f (was %.1f)",
+			r.period, r.oversoldLevel, currentRSI, prevRSI)
 		// Strength increases as RSI gets lower (more oversold)
 		strength = (r.oversoldLevel - currentRSI) / r.oversoldLevel
 		if strength > 1.0 {
@@ -67,6 +85,8 @@ func (r *RSIStrategy) Analyze(symbol string, bars []alpaca.MockBar, currentPrice
 	} else if prevRSI < r.overboughtLevel && currentRSI >= r.overboughtLevel {
 		// RSI crossed above overbought level - potential sell signal
 		signal = "SELL"
+		reason = fmt.Sprintf("RSI(%d) crossed above overbought %.0f: %.1f (was %.1f)",
+			r.period, r.overboughtLevel, currentRSI, prevRSI)
 		// Strength increases as RSI gets higher (more overbought)
 		strength = (currentRSI - r.overboughtLevel) / (100 - r.overboughtLevel)
 		if strength > 1.0 {
@@ -80,10 +100,12 @@ func (r *RSIStrategy) Analyze(symbol string, bars []alpaca.MockBar, currentPrice
 		// Extremely oversold condition
 		signal = "BUY"
 		strength = 0.9
+		reason = fmt.Sprintf("RSI(%d) extremely oversold: %.1f < 20", r.period, currentRSI)
 	} else if currentRSI > 80 {
 		// Extremely overbought condition
 		signal = "SELL"
 		strength = 0.9
+		reason = fmt.Sprintf("RSI(%d) extremely overbought: %.1f > 80", r.period, currentRSI)
 	} else {
 		// No clear signal
 		return nil
@@ -96,5 +118,33 @@ func (r *RSIStrategy) Analyze(symbol string, bars []alpaca.MockBar, currentPrice
 		Price:     currentPrice,
 		Strategy:  r.GetName(),
 		CreatedAt: time.Now(),
+		Indicators: map[string]float64{
+			"rsi":        currentRSI,
+			"prev_rsi":   prevRSI,
+			"oversold":   r.oversoldLevel,
+			"overbought": r.overboughtLevel,
+		},
+		Reason: reason,
+	}
+}
+
+// rsiState keeps a Wilder RSI up to date per bar
+type rsiState struct {
+	strategy *RSIStrategy
+	rsi      *indicators.StreamingRSI
+}
+
+func (st *rsiState) Update(bar alpaca.MockBar) {
+	st.rsi.Update(bar.Close)
+}
+
+func (st *rsiState) Signal(symbol string, currentPrice decimal.Decimal) *models.TradingSignal {
+	if !st.rsi.HasPrevious() {
+		return nil
 	}
+	return st.strategy.signal(symbol, currentPrice, st.rsi.Previous(), st.rsi.Value())
+}
+
+func (st *rsiState) Clone() IncrementalState {
+	return &rsiState{strategy: st.strategy, rsi: st.rsi.Clone()}
 }
@@ -0,0 +1,115 @@
+package strategies
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/MunishMummadi/mock-trade-algorithm/models"
+)
+
+// SectorRotationStrategy ranks sectors by the average trailing return of
+// their symbols, rotating into the strongest sector and out of the weakest
+type SectorRotationStrategy struct {
+	BaseStrategy
+	lookback int
+	sectorOf func(symbol string) string
+}
+
+// NewSectorRotationStrategy creates a new sector rotation strategy; sectorOf
+// maps a symbol to its sector, e.g. from the symbol registry
+func NewSectorRotationStrategy(lookback int, sectorOf func(symbol string) string) *SectorRotationStrategy {
+	return &SectorRotationStrategy{
+		BaseStrategy: BaseStrategy{
+			name:        "Sector Rotation",
+			description: "Rotates into the strongest sector and out of the weakest",
+		},
+		lookback: lookback,
+		sectorOf: sectorOf,
+	}
+}
+
+// AnalyzeUniverse implements the UniverseStrategy interface
+func (s *SectorRotationStrategy) AnalyzeUniverse(universe map[string]*Features, prices map[string]decimal.Decimal) []*models.TradingSignal {
+	members := make(map[string][]string)
+	totals := make(map[string]float64)
+
+	for _, symbol := range Universe(universe) {
+		if _, priced := prices[symbol]; !priced {
+			continue
+		}
+		momentum, ok := trailingReturn(universe[symbol], s.lookback)
+		if !ok {
+			continue
+		}
+		sector := s.sectorOf(symbol)
+		members[sector] = append(members[sector], symbol)
+		totals[sector] += momentum
+	}
+
+	if len(members) < 2 {
+		return nil
+	}
+
+	sectors := make([]string, 0, len(members))
+	for sector := range members {
+		sectors = append(sectors, sector)
+	}
+	sort.Strings(sectors)
+	sort.SliceStable(sectors, func(i, j int) bool {
+		return totals[sectors[i]]/float64(len(members[sectors[i]])) >
+			totals[sectors[j]]/float64(len(members[sectors[j]]))
+	})
+
+	strongest := sectors[0]
+	weakest := sectors[len(sectors)-1]
+	spread := totals[strongest]/float64(len(members[strongest])) -
+		totals[weakest]/float64(len(members[weakest]))
+
+	// Strength grows with the gap between the best and worst sector
+	strength := 0.6 + spread*5
+	if strength > 1.0 {
+		strength = 1.0
+	}
+
+	strongestReturn := totals[strongest] / float64(len(members[strongest]))
+	weakestReturn := totals[weakest] / float64(len(members[weakest]))
+	indicatorValues := map[string]float64{
+		"strongest_sector_return": strongestReturn,
+		"weakest_sector_return":   weakestReturn,
+		"spread":                  spread,
+	}
+
+	var signals []*models.TradingSignal
+	for _, symbol := range members[strongest] {
+		signals = append(signals, &models.TradingSignal{
+			Symbol:       symbol,
+			Signal:       "BUY",
+			Strength:     strength,
+			Price:        prices[symbol],
+			Strategy:     s.GetName(),
+			CreatedAt:    time.Now(),
+			TargetWeight: 1.0 / float64(len(members[strongest])),
+			Indicators:   indicatorValues,
+			Reason: fmt.Sprintf("%s is the strongest sector with a %d-bar return of %+.2f%%",
+				strongest, s.lookback, strongestReturn*100),
+		})
+	}
+	for _, symbol := range members[weakest] {
+		signals = append(signals, &models.TradingSignal{
+			Symbol:     symbol,
+			Signal:     "SELL",
+			Strength:   strength,
+			Price:      prices[symbol],
+			Strategy:   s.GetName(),
+			CreatedAt:  time.Now(),
+			Indicators: indicatorValues,
+			Reason: fmt.Sprintf("%s is the weakest sector with a %d-bar return of %+.2f%%",
+				weakest, s.lookback, weakestReturn*100),
+		})
+	}
+
+	return signals
+}
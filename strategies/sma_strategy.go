@@ -1,11 +1,13 @@
 package strategies
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/shopspring/decimal"
 
 	"github.com/MunishMummadi/mock-trade-algorithm/alpaca"
+	"github.com/MunishMummadi/mock-trade-algorithm/indicators"
 	"github.com/MunishMummadi/mock-trade-algorithm/models"
 )
 
@@ -30,15 +32,19 @@ func NewSMAStrategy(shortPeriod, longPeriod int) *SMAStrategy {
 
 // Analyze implements the Strategy interface
 func (s *SMAStrategy) Analyze(symbol string, bars []alpaca.MockBar, currentPrice decimal.Decimal) *models.TradingSignal {
-	if len(bars) < s.longPeriod {
+	return s.AnalyzeFeatures(NewFeatures(symbol, bars), currentPrice)
+}
+
+// AnalyzeFeatures implements the FeatureStrategy interface
+func (s *SMAStrategy) AnalyzeFeatures(features *Features, currentPrice decimal.Decimal) *models.TradingSignal {
+	symbol := features.Symbol
+	if features.Len() < s.longPeriod {
 		return nil
 	}
 
-	prices := ExtractPrices(bars)
-
 	// Calculate short and long SMAs
-	shortSMA := CalculateSMA(prices, s.shortPeriod)
-	longSMA := CalculateSMA(prices, s.longPeriod)
+	shortSMA := features.SMA(s.shortPeriod)
+	longSMA := features.SMA(s.longPeriod)
 
 	if len(shortSMA) < 2 || len(longSMA) < 2 {
 		return nil
@@ -50,33 +56,53 @@ func (s *SMAStrategy) Analyze(symbol string, bars []alpaca.MockBar, currentPrice
 	prevShortSMA := shortSMA[len(shortSMA)-2]
 	prevLongSMA := longSMA[len(longSMA)-2]
 
+	return s.signal(symbol, currentPrice, prevShortSMA, prevLongSMA, currentShortSMA, currentLongSMA)
+}
+
+// NewState implements the IncrementalStrategy interface
+func (s *SMAStrategy) NewState() IncrementalState {
+	return &smaState{
+		strategy: s,
+		short:    indicators.NewStreamingSMA(s.shortPeriod),
+		long:     indicators.NewStreamingSMA(s.longPeriod),
+	}
+}
+
+// signal turns the previous and current SMA values into a crossover signal
+func (s *SMAStrategy) signal(symbol string, currentPrice decimal.Decimal,
+	prevShortSMA, prevLongSMA, currentShortSMA, currentLongSMA float64) *models.TradingSignal {
+
 	// Determine signal
 	var signal string
 	var strength float64
 
 	// Check for crossover
-	if prevShortSMA.LessThanOrEqual(prevLongSMA) && currentShortSMA.GreaterThan(currentLongSMA) {
+	if prevShortSMA <= prevLongSMA && currentShortSMA > currentLongSMA {
 		// Bullish crossover - short SMA crosses above long SMA
 		signal = "BUY"
-		// Calculate strength based on the magnitude of the crossover
-		diff := currentShortSMA.Sub(currentLongSMA).Div(currentLongSMA).Abs()
-		strength = 0.7 + (diff.InexactFloat64() * 100 * 3) // Scale and limit strength
-		if strength > 1.0 {
-			strength = 1.0
-		}
-	} else if prevShortSMA.GreaterThanOrEqual(prevLongSMA) && currentShortSMA.LessThan(currentLongSMA) {
+	} else if prevShortSMA >= prevLongSMA && currentShortSMA < currentLongSMA {
 		// Bearish crossover - short SMA crosses below long SMA
 		signal = "SELL"
-		diff := currentLongSMA.Sub(currentShortSMA).Div(currentLongSMA).Abs()
-		strength = 0.7 + (diff.InexactFloat64() * 100 * 3)
-		if strength > 1.0 {
-			strength = 1.0
-		}
 	} else {
 		// No clear signal
 		return nil
 	}
 
+	// Calculate strength based on the magnitude of the crossover
+	diff := (currentShortSMA - currentLongSMA) / currentLongSMA
+	if diff < 0 {
+		diff = -diff
+	}
+	strength = 0.7 + (diff * 100 * 3) // Scale and limit strength
+	if strength > 1.0 {
+		strength = 1.0
+	}
+
+	direction := "above"
+	if signal == "SELL" {
+		direction = "below"
+	}
+
 	return &models.TradingSignal{
 		Symbol:    symbol,
 		Signal:    signal,
@@ -84,5 +110,44 @@ func (s *SMAStrategy) Analyze(symbol string, bars []alpaca.MockBar, currentPrice
 		Price:     currentPrice,
 		Strategy:  s.GetName(),
 		CreatedAt: time.Now(),
+		Indicators: map[string]float64{
+			"short_sma":      currentShortSMA,
+			"long_sma":       currentLongSMA,
+			"prev_short_sma": prevShortSMA,
+			"prev_long_sma":  prevLongSMA,
+		},
+		Reason: fmt.Sprintf("SMA(%d) crossed %s SMA(%d): %.2f vs %.2f (was %.2f vs %.2f)",
+			s.shortPeriod, direction, s.longPeriod, currentShortSMA, currentLongSMA, prevShortSMA, prevLongSMA),
+	}
+}
+
+// smaState keeps both SMAs and their previous values up to date per bar
+type smaState struct {
+	strategy  *SMAStrategy
+	short     *indicators.StreamingSMA
+	long      *indicators.StreamingSMA
+	prevShort float64
+	prevLong  float64
+	bars      int
+}
+
+func (st *smaState) Update(bar alpaca.MockBar) {
+	st.prevShort, st.prevLong = st.short.Value(), st.long.Value()
+	st.short.Update(bar.Close)
+	st.long.Update(bar.Close)
+	st.bars++
+}
+
+func (st *smaState) Signal(symbol string, currentPrice decimal.Decimal) *models.TradingSignal {
+	// Both averages need a previous full-window value
+	if st.bars <= st.strategy.longPeriod {
+		return nil
 	}
+	return st.strategy.signal(symbol, currentPrice, st.prevShort, st.prevLong, st.short.Value(), st.long.Value())
+}
+
+func (st *smaState) Clone() IncrementalState {
+	c := *st
+	c.short, c.long = st.short.Clone(), st.long.Clone()
+	return &c
 }
@@ -9,9 +9,24 @@ import (
 
 // Strategy interface that all trading strategies must implement
 type Strategy interface {
+	Describer
+
 	// Analyze takes historical data and current price, returns a trading signal
 	Analyze(symbol string, bars []alpaca.MockBar, currentPrice decimal.Decimal) *models.TradingSignal
+}
+
+// CheckedStrategy is implemented by strategies whose analysis can fail, such
+// as an external process breaking its protocol. The engine calls
+// AnalyzeChecked instead of Analyze so the supervisor counts the failure.
+type CheckedStrategy interface {
+	Strategy
+
+	// AnalyzeChecked is Analyze, returning why no signal was produced
+	AnalyzeChecked(symbol string, bars []alpaca.MockBar, currentPrice decimal.Decimal) (*models.TradingSignal, error)
+}
 
+// Describer is the part shared by every kind of strategy
+type Describer interface {
 	// GetName returns the strategy name
 	GetName() string
 
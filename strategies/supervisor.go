@@ -0,0 +1,281 @@
+package strategies
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/MunishMummadi/mock-trade-algorithm/models"
+)
+
+var (
+	// ErrQuarantined is returned for calls to a strategy that is quarantined
+	ErrQuarantined = errors.New("strategy is quarantined")
+
+	// ErrBusy is returned while an earlier call that timed out is still
+	// running, so the strategy's state is never touched by two calls at once
+	ErrBusy = errors.New("strategy is still running an earlier call")
+
+	// ErrHung is returned instead of ErrBusy once the earlier call has been
+	// running for MaxFailures timeouts. It quarantines the strategy, since a
+	// call that never returns would otherwise keep it busy forever.
+	ErrHung = errors.New("strategy has not returned from an earlier call")
+)
+
+// SupervisorConfig controls how misbehaving strategies are handled
+type SupervisorConfig struct {
+	Timeout     time.Duration // deadline of a single call
+	MaxFailures int           // consecutive failures before quarantine
+	Quarantine  time.Duration // how long a quarantined strategy is skipped
+}
+
+// Health is a strategy's track record under the supervisor
+type Health struct {
+	Strategy            string
+	Calls               int
+	Failures            int
+	Panics              int
+	Timeouts            int
+	ConsecutiveFailures int
+	Quarantines         int
+	QuarantinedUntil    time.Time
+	LastError           string
+
+	busy        bool
+	busySince   time.Time
+	quarantined bool
+}
+
+// Quarantined reports whether the strategy is being skipped at now
+func (h *Health) Quarantined(now time.Time) bool {
+	return now.Before(h.QuarantinedUntil)
+}
+
+// Supervisor runs strategy code in isolation: each call recovers from
+// panics and is abandoned when it misses its deadline. Strategies that fail
+// MaxFailures times in a row are quarantined. When the quarantine ends they
+// are on probation: failing again before their next success quarantines
+// them again.
+type Supervisor struct {
+	config SupervisorConfig
+
+	mu     sync.Mutex
+	health map[string]*Health
+}
+
+func NewSupervisor(cfg SupervisorConfig) *Supervisor {
+	return &Supervisor{
+		config: cfg,
+		health: make(map[string]*Health),
+	}
+}
+
+// Run calls fn on behalf of the named strategy. It returns ErrQuarantined,
+// ErrBusy or ErrHung without calling fn when the strategy is being skipped,
+// the parent context's error if it is cancelled, and otherwise fn's error
+// or the panic or timeout that stopped it.
+func (s *Supervisor) Run(ctx context.Context, strategy string, fn func() error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := s.begin(strategy); err != nil {
+		if errors.Is(err, ErrHung) {
+			s.record(strategy, err)
+		}
+		return err
+	}
+
+	callCtx, cancel := context.WithTimeout(ctx, s.config.Timeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		var err error
+		defer func() {
+			if r := recover(); r != nil {
+				err = &PanicError{Value: r, Stack: debug.Stack()}
+			}
+			s.end(strategy)
+			done <- err
+		}()
+		err = fn()
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-callCtx.Done():
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		err = &TimeoutError{Timeout: s.config.Timeout}
+	}
+
+	s.record(strategy, err)
+	return err
+}
+
+// PanicError is a recovered panic
+type PanicError struct {
+	Value interface{}
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// TimeoutError is a call that missed its deadline. The call keeps running
+// in the background until it returns; its result is discarded.
+type TimeoutError struct {
+	Timeout time.Duration
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("did not return within %s", e.Timeout)
+}
+
+func (s *Supervisor) begin(strategy string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	h := s.healthOf(strategy)
+	if h.Quarantined(now) {
+		return ErrQuarantined
+	}
+	if h.quarantined {
+		h.quarantined = false
+		h.ConsecutiveFailures = s.config.MaxFailures - 1
+		log.Printf("Strategy %s released from quarantine on probation", strategy)
+	}
+	if h.busy {
+		outstanding := now.Sub(h.busySince)
+		if outstanding > time.Duration(s.config.MaxFailures)*s.config.Timeout {
+			return fmt.Errorf("%w for %s", ErrHung, outstanding.Round(time.Second))
+		}
+		return ErrBusy
+	}
+
+	h.busy = true
+	h.busySince = now
+	h.Calls++
+	return nil
+}
+
+func (s *Supervisor) end(strategy string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.healthOf(strategy).busy = false
+}
+
+func (s *Supervisor) record(strategy string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	h := s.healthOf(strategy)
+	if err == nil {
+		h.ConsecutiveFailures = 0
+		return
+	}
+
+	h.Failures++
+	h.ConsecutiveFailures++
+	h.LastError = err.Error()
+	if errors.Is(err, ErrHung) && h.ConsecutiveFailures < s.config.MaxFailures {
+		h.ConsecutiveFailures = s.config.MaxFailures
+	}
+
+	var panicErr *PanicError
+	var timeoutErr *TimeoutError
+	switch {
+	case errors.As(err, &panicErr):
+		h.Panics++
+		log.Printf("Warning: strategy %s panicked: %v\n%s", strategy, panicErr.Value, panicErr.Stack)
+	case errors.As(err, &timeoutErr):
+		h.Timeouts++
+		log.Printf("Warning: strategy %s %v", strategy, err)
+	default:
+		log.Printf("Warning: strategy %s failed: %v", strategy, err)
+	}
+
+	if h.ConsecutiveFailures >= s.config.MaxFailures && !h.quarantined {
+		h.quarantined = true
+		h.Quarantines++
+		h.QuarantinedUntil = time.Now().Add(s.config.Quarantine)
+		log.Printf("Strategy %s quarantined until %s after %d consecutive failures (last: %s)",
+			strategy, h.QuarantinedUntil.Format("15:04:05"), h.ConsecutiveFailures, h.LastError)
+	}
+}
+
+func (s *Supervisor) healthOf(strategy string) *Health {
+	h, exists := s.health[strategy]
+	if !exists {
+		h = &Health{Strategy: strategy}
+		s.health[strategy] = h
+	}
+	return h
+}
+
+// Status returns a snapshot of every strategy's health, ordered by name
+func (s *Supervisor) Status() []Health {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	status := make([]Health, 0, len(s.health))
+	for _, h := range s.health {
+		status = append(status, *h)
+	}
+	sort.Slice(status, func(i, j int) bool {
+		return status[i].Strategy < status[j].Strategy
+	})
+	return status
+}
+
+// LogStatus logs one line summarizing the strategies that are quarantined
+// or have recently failed; healthy engines log nothing
+func (s *Supervisor) LogStatus() {
+	now := time.Now()
+	var quarantined, failing []string
+	for _, h := range s.Status() {
+		switch {
+		case h.Quarantined(now):
+			quarantined = append(quarantined, fmt.Sprintf("%s until %s", h.Strategy, h.QuarantinedUntil.Format("15:04:05")))
+		case h.ConsecutiveFailures > 0:
+			failing = append(failing, fmt.Sprintf("%s (%d in a row)", h.Strategy, h.ConsecutiveFailures))
+		}
+	}
+
+	if len(quarantined) > 0 || len(failing) > 0 {
+		log.Printf("Strategy health: quarantined [%s], failing [%s]",
+			strings.Join(quarantined, ", "), strings.Join(failing, ", "))
+	}
+}
+
+// ValidateSignal checks a signal a strategy returned for symbol, so
+// malformed output counts as a failure instead of reaching the order path.
+// An empty symbol skips the symbol check.
+func ValidateSignal(signal *models.TradingSignal, symbol string) error {
+	switch signal.Signal {
+	case "BUY", "SELL", "HOLD":
+	default:
+		return fmt.Errorf("invalid signal %q", signal.Signal)
+	}
+	if math.IsNaN(signal.Strength) || signal.Strength < 0 || signal.Strength > 1 {
+		return fmt.Errorf("strength %v is outside [0, 1]", signal.Strength)
+	}
+	if symbol != "" && signal.Symbol != symbol {
+		return fmt.Errorf("signal for %s returned while analyzing %s", signal.Symbol, symbol)
+	}
+	if !signal.Price.IsPositive() && len(signal.Legs) == 0 {
+		return fmt.Errorf("signal for %s has no price", signal.Symbol)
+	}
+	return nil
+}
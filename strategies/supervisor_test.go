@@ -0,0 +1,45 @@
+package strategies
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSupervisorQuarantinesHungCall(t *testing.T) {
+	s := NewSupervisor(SupervisorConfig{Timeout: 10 * time.Millisecond, MaxFailures: 3, Quarantine: time.Hour})
+	ctx := context.Background()
+
+	release := make(chan struct{})
+	defer close(release)
+	hang := func() error {
+		<-release
+		return nil
+	}
+
+	var timeout *TimeoutError
+	if err := s.Run(ctx, "Hung", hang); !errors.As(err, &timeout) {
+		t.Fatalf("first call: got %v, want a timeout", err)
+	}
+
+	// Calls shortly after the timeout are skipped without counting
+	if err := s.Run(ctx, "Hung", hang); !errors.Is(err, ErrBusy) {
+		t.Fatalf("call while busy: got %v, want ErrBusy", err)
+	}
+	if h := s.Status()[0]; h.ConsecutiveFailures != 1 || h.Quarantined(time.Now()) {
+		t.Fatalf("after ErrBusy: %d consecutive failures, quarantined %v", h.ConsecutiveFailures, h.Quarantined(time.Now()))
+	}
+
+	// Once the call has been outstanding for MaxFailures timeouts it is hung
+	time.Sleep(40 * time.Millisecond)
+	if err := s.Run(ctx, "Hung", hang); !errors.Is(err, ErrHung) {
+		t.Fatalf("call after three timeouts: got %v, want ErrHung", err)
+	}
+	if h := s.Status()[0]; !h.Quarantined(time.Now()) || h.Quarantines != 1 {
+		t.Fatalf("hung call did not quarantine: %+v", h)
+	}
+	if err := s.Run(ctx, "Hung", hang); !errors.Is(err, ErrQuarantined) {
+		t.Fatalf("call in quarantine: got %v, want ErrQuarantined", err)
+	}
+}
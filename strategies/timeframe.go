@@ -0,0 +1,96 @@
+package strategies
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/MunishMummadi/mock-trade-algorithm/alpaca"
+)
+
+// Timeframe is a bar interval, named like the Alpaca timeframes
+type Timeframe string
+
+const (
+	TimeframeHour  Timeframe = "1Hour"
+	Timeframe4Hour Timeframe = "4Hour"
+	TimeframeDay   Timeframe = "1Day"
+	TimeframeWeek  Timeframe = "1Week"
+	TimeframeMonth Timeframe = "1Month"
+)
+
+// timeframeLengths orders the timeframes by their nominal length
+var timeframeLengths = map[Timeframe]time.Duration{
+	TimeframeHour:  time.Hour,
+	Timeframe4Hour: 4 * time.Hour,
+	TimeframeDay:   24 * time.Hour,
+	TimeframeWeek:  7 * 24 * time.Hour,
+	TimeframeMonth: 30 * 24 * time.Hour,
+}
+
+// ParseTimeframe parses a timeframe name, ignoring case
+func ParseTimeframe(name string) (Timeframe, error) {
+	for tf := range timeframeLengths {
+		if strings.EqualFold(string(tf), strings.TrimSpace(name)) {
+			return tf, nil
+		}
+	}
+	return "", fmt.Errorf("unknown timeframe %q (use 1Hour, 4Hour, 1Day, 1Week or 1Month)", name)
+}
+
+// Length returns the timeframe's nominal length, a month counting as 30 days
+func (tf Timeframe) Length() time.Duration {
+	return timeframeLengths[tf]
+}
+
+// Start returns the start of the bar that t falls in. Weeks start on Monday;
+// days, weeks and months follow t's location.
+func (tf Timeframe) Start(t time.Time) time.Time {
+	switch tf {
+	case TimeframeHour:
+		return t.Truncate(time.Hour)
+	case Timeframe4Hour:
+		day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+		return day.Add(time.Duration(t.Hour()/4*4) * time.Hour)
+	case TimeframeWeek:
+		day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	case TimeframeMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	default:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	}
+}
+
+// Resample aggregates bars into bars of the timeframe: the open of the first
+// bar, the highest high, the lowest low, the close of the last bar and the
+// total volume, stamped with the start of the period. The last bar is
+// usually still forming and covers the period so far. Bars that are already
+// at least as coarse as the timeframe are returned unchanged.
+func Resample(bars []alpaca.MockBar, tf Timeframe) []alpaca.MockBar {
+	var resampled []alpaca.MockBar
+	for _, bar := range bars {
+		start := tf.Start(bar.Timestamp)
+		last := len(resampled) - 1
+		if last < 0 || !resampled[last].Timestamp.Equal(start) {
+			bar.Timestamp = start
+			resampled = append(resampled, bar)
+			continue
+		}
+
+		current := &resampled[last]
+		if bar.High > current.High {
+			current.High = bar.High
+		}
+		if bar.Low < current.Low {
+			current.Low = bar.Low
+		}
+		current.Close = bar.Close
+		current.Volume += bar.Volume
+	}
+
+	if len(resampled) == len(bars) {
+		return bars
+	}
+	return resampled
+}
@@ -0,0 +1,53 @@
+package strategies
+
+import (
+	"sort"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/MunishMummadi/mock-trade-algorithm/models"
+)
+
+// UniverseStrategy is a cross-sectional strategy: it sees every symbol's
+// features at once and may return signals, optionally with target weights,
+// for any number of symbols. It is a separate interface from Strategy so
+// ranking, relative strength and pairs strategies don't have to fake a
+// per-symbol Analyze.
+type UniverseStrategy interface {
+	Describer
+
+	// AnalyzeUniverse takes the feature frame and current price of every
+	// tradable symbol and returns zero or more signals
+	AnalyzeUniverse(universe map[string]*Features, prices map[string]decimal.Decimal) []*models.TradingSignal
+}
+
+// PositionHolder is implemented by universe strategies that manage
+// positions of their own, such as the legs of a pair, which only the
+// strategy may close
+type PositionHolder interface {
+	// HeldSymbols returns the symbols the strategy holds or is entering
+	HeldSymbols() []string
+}
+
+// Universe is a helper for iterating a universe in a stable order
+func Universe(universe map[string]*Features) []string {
+	symbols := make([]string, 0, len(universe))
+	for symbol := range universe {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+	return symbols
+}
+
+// trailingReturn returns the close-to-close return over the last lookback bars
+func trailingReturn(features *Features, lookback int) (float64, bool) {
+	closes := features.Closes()
+	if lookback <= 0 || len(closes) <= lookback {
+		return 0, false
+	}
+	start := closes[len(closes)-1-lookback]
+	if start == 0 {
+		return 0, false
+	}
+	return closes[len(closes)-1]/start - 1, true
+}
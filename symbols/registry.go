@@ -0,0 +1,144 @@
+package symbols
+
+import (
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/MunishMummadi/mock-trade-algorithm/database"
+	"github.com/MunishMummadi/mock-trade-algorithm/models"
+)
+
+// Registry holds metadata for every tradable symbol
+type Registry struct {
+	db     *database.Database
+	assets map[string]*models.Asset
+}
+
+// DefaultAssets returns the symbols the registry is seeded with on first run
+func DefaultAssets() []*models.Asset {
+	return []*models.Asset{
+		models.NewEquityAsset("AAPL", "NASDAQ", "Technology", 175.50),
+		models.NewEquityAsset("GOOGL", "NASDAQ", "Communication Services", 135.25),
+		models.NewEquityAsset("MSFT", "NASDAQ", "Technology", 378.85),
+		models.NewEquityAsset("TSLA", "NASDAQ", "Consumer Discretionary", 238.45),
+		models.NewEquityAsset("AMZN", "NASDAQ", "Consumer Discretionary", 145.30),
+		models.NewEquityAsset("NVDA", "NASDAQ", "Technology", 875.25),
+		models.NewEquityAsset("META", "NASDAQ", "Communication Services", 485.60),
+		models.NewEquityAsset("NFLX", "NASDAQ", "Communication Services", 425.75),
+		models.NewCryptoAsset("BTC/USD", 0.0001, 64250.00),
+		models.NewCryptoAsset("ETH/USD", 0.001, 3150.40),
+		models.NewCryptoAsset("SOL/USD", 0.01, 145.85),
+	}
+}
+
+// Load reads the registry from the database, seeding it with DefaultAssets
+// when the symbols table is empty
+func Load(db *database.Database) (*Registry, error) {
+	assets, err := db.GetSymbols()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load symbols: %w", err)
+	}
+
+	if len(assets) == 0 {
+		log.Println("Symbol registry is empty, seeding default symbols")
+		assets = DefaultAssets()
+		for _, asset := range assets {
+			if err := db.UpsertSymbol(asset); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	registry := &Registry{
+		db:     db,
+		assets: make(map[string]*models.Asset, len(assets)),
+	}
+	for _, asset := range assets {
+		registry.assets[asset.Symbol] = asset
+	}
+
+	log.Printf("Loaded %d symbols into registry", len(registry.assets))
+	return registry, nil
+}
+
+// Register adds or updates a symbol and persists it
+func (r *Registry) Register(asset *models.Asset) error {
+	if err := r.db.UpsertSymbol(asset); err != nil {
+		return err
+	}
+	r.assets[asset.Symbol] = asset
+	return nil
+}
+
+// Lookup returns the metadata for a symbol
+func (r *Registry) Lookup(symbol string) (*models.Asset, error) {
+	asset, exists := r.assets[symbol]
+	if !exists {
+		return nil, fmt.Errorf("unknown symbol %q", symbol)
+	}
+	return asset, nil
+}
+
+// All returns every registered asset ordered by symbol
+func (r *Registry) All() []*models.Asset {
+	assets := make([]*models.Asset, 0, len(r.assets))
+	for _, asset := range r.assets {
+		assets = append(assets, asset)
+	}
+	sort.Slice(assets, func(i, j int) bool {
+		return assets[i].Symbol < assets[j].Symbol
+	})
+	return assets
+}
+
+// Watchlist returns the symbols of all active assets
+func (r *Registry) Watchlist() []string {
+	var symbols []string
+	for _, asset := range r.All() {
+		if asset.Active {
+			symbols = append(symbols, asset.Symbol)
+		}
+	}
+	return symbols
+}
+
+// ValidateOrder checks a trade against the symbol's metadata and rounds its
+// quantity to whole lots and its price to the tick size
+func (r *Registry) ValidateOrder(trade *models.Trade, currentPosition decimal.Decimal) error {
+	asset, err := r.Lookup(trade.Symbol)
+	if err != nil {
+		return err
+	}
+	if !asset.Active {
+		return fmt.Errorf("symbol %s is not active", trade.Symbol)
+	}
+
+	trade.Quantity = asset.RoundQuantity(trade.Quantity)
+	if trade.Quantity.LessThan(asset.MinLot) || !trade.Quantity.IsPositive() {
+		return fmt.Errorf("quantity for %s is below the minimum lot of %s", trade.Symbol, asset.MinLot.String())
+	}
+	trade.Price = asset.RoundPrice(trade.Price)
+
+	// Selling more than we hold opens a short position
+	if trade.Side == models.OrderSideSell && trade.Quantity.GreaterThan(currentPosition) && !asset.Shortable {
+		return fmt.Errorf("symbol %s is not shortable", trade.Symbol)
+	}
+
+	return nil
+}
+
+// SectorExposure sums the current value of positions per sector
+func (r *Registry) SectorExposure(portfolio []*models.Portfolio) map[string]decimal.Decimal {
+	exposure := make(map[string]decimal.Decimal)
+	for _, position := range portfolio {
+		sector := "Unknown"
+		if asset, err := r.Lookup(position.Symbol); err == nil {
+			sector = asset.Sector
+		}
+		exposure[sector] = exposure[sector].Add(position.CurrentValue.Abs())
+	}
+	return exposure
+}